@@ -0,0 +1,386 @@
+package coap
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	mathrand "math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+// Parámetros de transmisión por defecto (RFC 7252 §4.8)
+const (
+	defaultAckTimeout    = 2 * time.Second
+	defaultMaxRetransmit = 4
+	defaultNStart        = 1
+	ackRandomFactor      = 1.5
+)
+
+const (
+	// defaultQueueSize es la cantidad de mensajes CON que esperan turno
+	defaultQueueSize = 256
+	// retransmitCheck es cada cuánto se revisan los vencimientos de ACK
+	retransmitCheck = 50 * time.Millisecond
+)
+
+// Config define el servidor destino y el recurso observable opcional.
+// ServerAddr recibe los POST (host:puerto, normalmente :5683); ObserveAddr,
+// si no está vacío, expone cada topic como recurso GET observable.
+// Los mensajes CON se envían en background: QueueSize limita los que esperan
+// y NStart los que esperan ACK a la vez; con la cola llena se descartan.
+type Config struct {
+	ServerAddr    string
+	Confirmable   bool
+	AckTimeout    time.Duration
+	MaxRetransmit int
+	NStart        int
+	QueueSize     int
+	ObserveAddr   string
+}
+
+// CoAPPublisher implementa un publicador CoAP sobre UDP
+type CoAPPublisher struct {
+	cfg      Config
+	mu       sync.Mutex
+	conn     *net.UDPConn
+	observe  *observeServer
+	nextMID  uint16
+	outbox   chan *Message
+	replies  chan *Message
+	stopChan chan struct{}
+	doneChan chan struct{}
+	open     bool
+	dropping bool
+	dropped  int
+	logger   *slog.Logger
+}
+
+// exchange es un mensaje CON que espera su ACK
+type exchange struct {
+	msg      *Message
+	attempts int
+	timeout  time.Duration
+	deadline time.Time
+}
+
+// NewCoAPPublisher crea un nuevo publicador CoAP
+func NewCoAPPublisher(cfg Config) *CoAPPublisher {
+	if cfg.AckTimeout <= 0 {
+		cfg.AckTimeout = defaultAckTimeout
+	}
+	if cfg.MaxRetransmit <= 0 {
+		cfg.MaxRetransmit = defaultMaxRetransmit
+	}
+	if cfg.NStart <= 0 {
+		cfg.NStart = defaultNStart
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	return &CoAPPublisher{
+		cfg:     cfg,
+		nextMID: randomMessageID(),
+		logger:  logging.For("coap"),
+	}
+}
+
+// Connect abre el socket hacia el servidor y el servidor de observación
+func (p *CoAPPublisher) Connect() error {
+	if p.cfg.ServerAddr == "" && p.cfg.ObserveAddr == "" {
+		return fmt.Errorf("coap: se requiere ServerAddr u ObserveAddr")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.open {
+		return nil
+	}
+
+	if p.cfg.ServerAddr != "" {
+		raddr, err := net.ResolveUDPAddr("udp", p.cfg.ServerAddr)
+		if err != nil {
+			return err
+		}
+		conn, err := net.DialUDP("udp", nil, raddr)
+		if err != nil {
+			return fmt.Errorf("coap dial %s: %w", p.cfg.ServerAddr, err)
+		}
+		p.conn = conn
+		p.replies = make(chan *Message, max(p.cfg.NStart, 16))
+		go p.readLoop(conn, p.replies)
+		p.logger.Info("publicando por CoAP", "server", p.cfg.ServerAddr, "confirmable", p.cfg.Confirmable)
+	}
+
+	if p.cfg.ObserveAddr != "" {
+		observe, err := listenObserve(p.cfg.ObserveAddr, p.logger)
+		if err != nil {
+			if p.conn != nil {
+				p.conn.Close()
+				p.conn = nil
+			}
+			return err
+		}
+		p.observe = observe
+		p.logger.Info("recursos CoAP observables", "addr", observe.conn.LocalAddr().String())
+	}
+
+	p.stopChan = make(chan struct{})
+	p.doneChan = make(chan struct{})
+	if p.conn != nil && p.cfg.Confirmable {
+		p.outbox = make(chan *Message, p.cfg.QueueSize)
+		go p.deliverLoop(p.conn, p.outbox, p.replies, p.stopChan, p.doneChan)
+	} else {
+		close(p.doneChan)
+	}
+	p.open = true
+	return nil
+}
+
+// Publish envía la lectura como POST al path del topic y actualiza el recurso observable
+func (p *CoAPPublisher) Publish(topic string, payload interface{}) error {
+	data, err := domain.MarshalReading(payload)
+	if err != nil {
+		p.logger.Error("error serializando payload", "topic", topic, "error", err)
+		return err
+	}
+
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return nil
+	}
+	conn, observe, outbox := p.conn, p.observe, p.outbox
+	p.mu.Unlock()
+
+	if observe != nil {
+		observe.update(topic, data)
+	}
+	if conn == nil {
+		return nil
+	}
+
+	msg := &Message{
+		Type:    TypeNonConfirmable,
+		Code:    CodePost,
+		Token:   randomToken(),
+		Payload: data,
+	}
+	msg.SetPath(topic)
+	msg.SetUint(OptionContentFormat, FormatJSON)
+
+	if !p.cfg.Confirmable {
+		msg.MessageID = p.messageID()
+		return p.send(conn, msg)
+	}
+
+	// El ACK se espera en background para no frenar a los sensores ni al resto
+	// de los publicadores del fanout
+	msg.Type = TypeConfirmable
+	select {
+	case outbox <- msg:
+		p.setDropping(false)
+	default:
+		p.setDropping(true)
+	}
+	return nil
+}
+
+// setDropping avisa una sola vez cuando la cola se llena y cuando se libera
+func (p *CoAPPublisher) setDropping(dropping bool) {
+	p.mu.Lock()
+	changed := p.dropping != dropping
+	p.dropping = dropping
+	if dropping {
+		p.dropped++
+	}
+	dropped := p.dropped
+	if changed && !dropping {
+		p.dropped = 0
+	}
+	p.mu.Unlock()
+
+	switch {
+	case changed && dropping:
+		p.logger.Warn("cola CON llena, descartando mensajes", "queue", p.cfg.QueueSize)
+	case changed:
+		p.logger.Info("cola CON disponible", "dropped", dropped)
+	}
+}
+
+// deliverLoop envía los mensajes CON y retransmite con espera exponencial
+// hasta recibir ACK o RST, con hasta NStart mensajes sin confirmar a la vez
+func (p *CoAPPublisher) deliverLoop(conn *net.UDPConn, outbox <-chan *Message, replies <-chan *Message, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(retransmitCheck)
+	defer ticker.Stop()
+
+	inflight := make(map[uint16]*exchange)
+	for {
+		// Con NStart mensajes sin confirmar no se toma uno nuevo de la cola
+		next := outbox
+		if len(inflight) >= p.cfg.NStart {
+			next = nil
+		}
+
+		select {
+		case <-stop:
+			if pending := len(inflight) + len(outbox); pending > 0 {
+				p.logger.Warn("publicador desconectado con mensajes CON sin confirmar", "pending", pending)
+			}
+			return
+
+		case msg := <-next:
+			msg.MessageID = p.messageID()
+			timeout := time.Duration(float64(p.cfg.AckTimeout) * (1 + mathrand.Float64()*(ackRandomFactor-1)))
+			inflight[msg.MessageID] = &exchange{msg: msg, timeout: timeout, deadline: time.Now().Add(timeout)}
+			p.send(conn, msg)
+
+		case resp := <-replies:
+			ex, ok := inflight[resp.MessageID]
+			if !ok {
+				continue
+			}
+			delete(inflight, resp.MessageID)
+			if err := checkResponse(ex.msg, resp); err != nil {
+				p.logger.Error("error publicando", "path", ex.msg.Path(), "error", err)
+				continue
+			}
+			p.logger.Debug("mensaje confirmado", "path", ex.msg.Path(), "mid", resp.MessageID)
+
+		case now := <-ticker.C:
+			for mid, ex := range inflight {
+				if now.Before(ex.deadline) {
+					continue
+				}
+				if ex.attempts >= p.cfg.MaxRetransmit {
+					delete(inflight, mid)
+					err := fmt.Errorf("coap: sin ACK para %s tras %d retransmisiones", ex.msg.Path(), p.cfg.MaxRetransmit)
+					p.logger.Error("error publicando", "path", ex.msg.Path(), "error", err)
+					continue
+				}
+				ex.attempts++
+				ex.timeout *= 2
+				ex.deadline = now.Add(ex.timeout)
+				p.logger.Debug("retransmitiendo CON", "path", ex.msg.Path(), "mid", mid, "attempt", ex.attempts)
+				p.send(conn, ex.msg)
+			}
+		}
+	}
+}
+
+// checkResponse retorna un error si el servidor respondió RST o un código 4.xx/5.xx
+func checkResponse(req, resp *Message) error {
+	if resp.Type == TypeReset {
+		return fmt.Errorf("coap: el servidor rechazó %s (RST)", req.Path())
+	}
+	if class := resp.Code >> 5; class >= 4 {
+		return fmt.Errorf("coap: %s respondió %d.%02d", req.Path(), class, resp.Code&0x1F)
+	}
+	return nil
+}
+
+// readLoop entrega los ACK y RST al envío en background
+func (p *CoAPPublisher) readLoop(conn *net.UDPConn, replies chan<- *Message) {
+	buf := make([]byte, maxDatagram)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				p.logger.Debug("error leyendo del servidor CoAP", "error", err)
+				continue
+			}
+			return
+		}
+
+		msg, err := Unmarshal(buf[:n])
+		if err != nil {
+			continue
+		}
+
+		switch msg.Type {
+		case TypeAcknowledgement, TypeReset:
+			select {
+			case replies <- msg:
+			default:
+			}
+		case TypeConfirmable:
+			// Respuesta separada: se confirma aunque ya no haya nadie esperando
+			p.send(conn, &Message{Type: TypeAcknowledgement, MessageID: msg.MessageID})
+		}
+	}
+}
+
+func (p *CoAPPublisher) send(conn *net.UDPConn, msg *Message) error {
+	data, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(data); err != nil {
+		p.logger.Error("error enviando datagrama CoAP", "path", msg.Path(), "error", err)
+		return err
+	}
+	return nil
+}
+
+// IsConnected indica si el publicador está abierto
+func (p *CoAPPublisher) IsConnected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.open
+}
+
+// Disconnect cierra los sockets y descarta los mensajes CON pendientes
+func (p *CoAPPublisher) Disconnect() {
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return
+	}
+	p.open = false
+	close(p.stopChan)
+	conn, observe, done := p.conn, p.observe, p.doneChan
+	p.conn, p.observe, p.outbox = nil, nil, nil
+	p.mu.Unlock()
+
+	<-done
+
+	if conn != nil {
+		conn.Close()
+	}
+	if observe != nil {
+		observe.close()
+	}
+	p.logger.Info("publicador CoAP cerrado")
+}
+
+func (p *CoAPPublisher) messageID() uint16 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.nextMessageIDLocked()
+}
+
+func (p *CoAPPublisher) nextMessageIDLocked() uint16 {
+	p.nextMID++
+	return p.nextMID
+}
+
+func randomToken() []byte {
+	token := make([]byte, 4)
+	rand.Read(token)
+	return token
+}
+
+func randomMessageID() uint16 {
+	var b [2]byte
+	rand.Read(b[:])
+	return binary.BigEndian.Uint16(b[:])
+}
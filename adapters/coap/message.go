@@ -0,0 +1,231 @@
+package coap
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Tipos de mensaje (RFC 7252 §3)
+const (
+	TypeConfirmable     uint8 = 0
+	TypeNonConfirmable  uint8 = 1
+	TypeAcknowledgement uint8 = 2
+	TypeReset           uint8 = 3
+)
+
+// Códigos de método y respuesta usados por el adaptador (clase.detalle)
+const (
+	CodeEmpty            uint8 = 0x00
+	CodeGet              uint8 = 0x01
+	CodePost             uint8 = 0x02
+	CodeCreated          uint8 = 0x41 // 2.01
+	CodeChanged          uint8 = 0x44 // 2.04
+	CodeContent          uint8 = 0x45 // 2.05
+	CodeBadRequest       uint8 = 0x80 // 4.00
+	CodeNotFound         uint8 = 0x84 // 4.04
+	CodeMethodNotAllowed uint8 = 0x85 // 4.05
+)
+
+// Números de opción usados por el adaptador
+const (
+	OptionObserve       uint16 = 6
+	OptionURIPath       uint16 = 11
+	OptionContentFormat uint16 = 12
+)
+
+// Formatos de contenido
+const (
+	FormatLinkFormat uint32 = 40
+	FormatJSON       uint32 = 50
+)
+
+const payloadMarker = 0xFF
+
+var errMalformed = errors.New("coap: mensaje mal formado")
+
+// Option es una opción CoAP ya decodificada
+type Option struct {
+	Number uint16
+	Value  []byte
+}
+
+// Message es un mensaje CoAP sobre UDP
+type Message struct {
+	Type      uint8
+	Code      uint8
+	MessageID uint16
+	Token     []byte
+	Options   []Option
+	Payload   []byte
+}
+
+// Path une las opciones Uri-Path con "/"
+func (m *Message) Path() string {
+	var segments []string
+	for _, opt := range m.Options {
+		if opt.Number == OptionURIPath {
+			segments = append(segments, string(opt.Value))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// SetPath reemplaza las opciones Uri-Path por los segmentos de path
+func (m *Message) SetPath(path string) {
+	kept := m.Options[:0]
+	for _, opt := range m.Options {
+		if opt.Number != OptionURIPath {
+			kept = append(kept, opt)
+		}
+	}
+	m.Options = kept
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment != "" {
+			m.Options = append(m.Options, Option{Number: OptionURIPath, Value: []byte(segment)})
+		}
+	}
+}
+
+// Uint retorna el valor entero de la primera opción con ese número
+func (m *Message) Uint(number uint16) (uint32, bool) {
+	for _, opt := range m.Options {
+		if opt.Number == number {
+			var v uint32
+			for _, b := range opt.Value {
+				v = v<<8 | uint32(b)
+			}
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// SetUint agrega una opción entera con la codificación mínima
+func (m *Message) SetUint(number uint16, v uint32) {
+	var buf []byte
+	for v > 0 {
+		buf = append([]byte{byte(v)}, buf...)
+		v >>= 8
+	}
+	m.Options = append(m.Options, Option{Number: number, Value: buf})
+}
+
+// Marshal codifica el mensaje según RFC 7252 §3
+func (m *Message) Marshal() ([]byte, error) {
+	if len(m.Token) > 8 {
+		return nil, errors.New("coap: token de más de 8 bytes")
+	}
+
+	buf := make([]byte, 4, 4+len(m.Token)+len(m.Payload)+32)
+	buf[0] = 1<<6 | m.Type<<4 | uint8(len(m.Token))
+	buf[1] = m.Code
+	binary.BigEndian.PutUint16(buf[2:], m.MessageID)
+	buf = append(buf, m.Token...)
+
+	options := append([]Option(nil), m.Options...)
+	sort.SliceStable(options, func(i, j int) bool { return options[i].Number < options[j].Number })
+
+	var last uint16
+	for _, opt := range options {
+		delta := int(opt.Number - last)
+		last = opt.Number
+
+		deltaNibble, deltaExt := optionNibble(delta)
+		lengthNibble, lengthExt := optionNibble(len(opt.Value))
+		buf = append(buf, deltaNibble<<4|lengthNibble)
+		buf = append(buf, deltaExt...)
+		buf = append(buf, lengthExt...)
+		buf = append(buf, opt.Value...)
+	}
+
+	if len(m.Payload) > 0 {
+		buf = append(buf, payloadMarker)
+		buf = append(buf, m.Payload...)
+	}
+	return buf, nil
+}
+
+// Unmarshal decodifica un datagrama CoAP
+func Unmarshal(data []byte) (*Message, error) {
+	if len(data) < 4 || data[0]>>6 != 1 {
+		return nil, errMalformed
+	}
+	tkl := int(data[0] & 0x0F)
+	if tkl > 8 || len(data) < 4+tkl {
+		return nil, errMalformed
+	}
+
+	m := &Message{
+		Type:      (data[0] >> 4) & 0x03,
+		Code:      data[1],
+		MessageID: binary.BigEndian.Uint16(data[2:]),
+		Token:     append([]byte(nil), data[4:4+tkl]...),
+	}
+
+	rest := data[4+tkl:]
+	var number int
+	for len(rest) > 0 {
+		if rest[0] == payloadMarker {
+			if len(rest) == 1 {
+				return nil, errMalformed
+			}
+			m.Payload = append([]byte(nil), rest[1:]...)
+			break
+		}
+
+		header := rest[0]
+		rest = rest[1:]
+
+		delta, n, err := optionValue(int(header>>4), rest)
+		if err != nil {
+			return nil, err
+		}
+		rest = rest[n:]
+		length, n, err := optionValue(int(header&0x0F), rest)
+		if err != nil {
+			return nil, err
+		}
+		rest = rest[n:]
+		if len(rest) < length {
+			return nil, errMalformed
+		}
+
+		number += delta
+		m.Options = append(m.Options, Option{Number: uint16(number), Value: append([]byte(nil), rest[:length]...)})
+		rest = rest[length:]
+	}
+	return m, nil
+}
+
+func optionNibble(v int) (uint8, []byte) {
+	switch {
+	case v < 13:
+		return uint8(v), nil
+	case v < 269:
+		return 13, []byte{byte(v - 13)}
+	default:
+		ext := make([]byte, 2)
+		binary.BigEndian.PutUint16(ext, uint16(v-269))
+		return 14, ext
+	}
+}
+
+func optionValue(nibble int, rest []byte) (int, int, error) {
+	switch nibble {
+	case 13:
+		if len(rest) < 1 {
+			return 0, 0, errMalformed
+		}
+		return int(rest[0]) + 13, 1, nil
+	case 14:
+		if len(rest) < 2 {
+			return 0, 0, errMalformed
+		}
+		return int(binary.BigEndian.Uint16(rest)) + 269, 2, nil
+	case 15:
+		return 0, 0, errMalformed
+	}
+	return nibble, 0, nil
+}
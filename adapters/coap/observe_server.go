@@ -0,0 +1,227 @@
+package coap
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	wellKnownCore = ".well-known/core"
+	maxDatagram   = 1500
+	// El número de secuencia de Observe ocupa 24 bits (RFC 7641 §4.4)
+	observeSeqMask = 1<<24 - 1
+)
+
+// observeServer expone cada topic como recurso observable (RFC 7641)
+type observeServer struct {
+	conn      *net.UDPConn
+	mu        sync.Mutex
+	resources map[string]*resource
+	nextMID   uint16
+	logger    *slog.Logger
+}
+
+type resource struct {
+	payload   []byte
+	seq       uint32
+	observers map[string]*observer
+}
+
+type observer struct {
+	addr    *net.UDPAddr
+	token   []byte
+	lastMID uint16
+}
+
+func listenObserve(addr string, logger *slog.Logger) (*observeServer, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, fmt.Errorf("coap listen %s: %w", addr, err)
+	}
+
+	s := &observeServer{
+		conn:      conn,
+		resources: make(map[string]*resource),
+		nextMID:   randomMessageID(),
+		logger:    logger,
+	}
+	go s.serve()
+	return s, nil
+}
+
+// update guarda la última representación y notifica a los observadores
+func (s *observeServer) update(path string, payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.resources[path]
+	if !ok {
+		res = &resource{observers: make(map[string]*observer)}
+		s.resources[path] = res
+	}
+	res.payload = payload
+	res.seq = (res.seq + 1) & observeSeqMask
+
+	for key, obs := range res.observers {
+		msg := &Message{
+			Type:      TypeNonConfirmable,
+			Code:      CodeContent,
+			MessageID: s.messageID(),
+			Token:     obs.token,
+			Payload:   payload,
+		}
+		msg.SetUint(OptionObserve, res.seq)
+		msg.SetUint(OptionContentFormat, FormatJSON)
+		obs.lastMID = msg.MessageID
+
+		if err := s.send(msg, obs.addr); err != nil {
+			s.logger.Debug("observador eliminado", "path", path, "observer", key, "error", err)
+			delete(res.observers, key)
+		}
+	}
+}
+
+func (s *observeServer) close() error {
+	return s.conn.Close()
+}
+
+func (s *observeServer) serve() {
+	buf := make([]byte, maxDatagram)
+	for {
+		n, addr, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.logger.Error("error leyendo datagrama CoAP", "error", err)
+			}
+			return
+		}
+
+		req, err := Unmarshal(buf[:n])
+		if err != nil {
+			s.logger.Debug("datagrama CoAP descartado", "remote", addr.String(), "error", err)
+			continue
+		}
+		s.handle(req, addr)
+	}
+}
+
+func (s *observeServer) handle(req *Message, addr *net.UDPAddr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.Type {
+	case TypeReset:
+		// Un RST en respuesta a una notificación cancela la observación
+		s.cancelByMessageID(addr, req.MessageID)
+		return
+	case TypeAcknowledgement:
+		return
+	}
+
+	resp := s.response(req)
+	if req.Code != CodeGet {
+		resp.Code = CodeMethodNotAllowed
+		s.reply(resp, addr)
+		return
+	}
+
+	path := req.Path()
+	if path == wellKnownCore {
+		resp.Code = CodeContent
+		resp.SetUint(OptionContentFormat, FormatLinkFormat)
+		resp.Payload = s.linkFormat()
+		s.reply(resp, addr)
+		return
+	}
+
+	res, ok := s.resources[path]
+	if !ok {
+		resp.Code = CodeNotFound
+		s.reply(resp, addr)
+		return
+	}
+
+	key := observerKey(addr, req.Token)
+	if value, ok := req.Uint(OptionObserve); ok {
+		switch value {
+		case 0:
+			res.observers[key] = &observer{addr: addr, token: req.Token}
+			s.logger.Info("observador registrado", "path", path, "remote", addr.String())
+			resp.SetUint(OptionObserve, res.seq)
+		case 1:
+			delete(res.observers, key)
+		}
+	}
+
+	resp.Code = CodeContent
+	resp.SetUint(OptionContentFormat, FormatJSON)
+	resp.Payload = res.payload
+	s.reply(resp, addr)
+}
+
+// response prepara la respuesta: piggyback en el ACK si la petición es CON
+func (s *observeServer) response(req *Message) *Message {
+	if req.Type == TypeConfirmable {
+		return &Message{Type: TypeAcknowledgement, MessageID: req.MessageID, Token: req.Token}
+	}
+	return &Message{Type: TypeNonConfirmable, MessageID: s.messageID(), Token: req.Token}
+}
+
+func (s *observeServer) reply(resp *Message, addr *net.UDPAddr) {
+	if err := s.send(resp, addr); err != nil {
+		s.logger.Debug("error respondiendo CoAP", "remote", addr.String(), "error", err)
+	}
+}
+
+func (s *observeServer) send(msg *Message, addr *net.UDPAddr) error {
+	data, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = s.conn.WriteToUDP(data, addr)
+	return err
+}
+
+func (s *observeServer) cancelByMessageID(addr *net.UDPAddr, mid uint16) {
+	for path, res := range s.resources {
+		for key, obs := range res.observers {
+			if obs.lastMID == mid && obs.addr.String() == addr.String() {
+				delete(res.observers, key)
+				s.logger.Info("observación cancelada", "path", path, "remote", addr.String())
+			}
+		}
+	}
+}
+
+// linkFormat describe los recursos disponibles (RFC 6690)
+func (s *observeServer) linkFormat() []byte {
+	paths := make([]string, 0, len(s.resources))
+	for path := range s.resources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	links := make([]string, len(paths))
+	for i, path := range paths {
+		links[i] = fmt.Sprintf("</%s>;obs;ct=%d", path, FormatJSON)
+	}
+	return []byte(strings.Join(links, ","))
+}
+
+func (s *observeServer) messageID() uint16 {
+	s.nextMID++
+	return s.nextMID
+}
+
+func observerKey(addr *net.UDPAddr, token []byte) string {
+	return fmt.Sprintf("%s/%x", addr.String(), token)
+}
@@ -0,0 +1,59 @@
+package fanout
+
+import (
+	"errors"
+
+	"simulador-hard/ports"
+)
+
+// FanoutPublisher reenvía cada mensaje a varios publicadores
+type FanoutPublisher struct {
+	publishers []ports.DataPublisher
+}
+
+// NewFanoutPublisher crea un publicador que agrupa a los indicados
+func NewFanoutPublisher(publishers ...ports.DataPublisher) *FanoutPublisher {
+	return &FanoutPublisher{publishers: publishers}
+}
+
+// Publish publica en todos los publicadores conectados
+func (f *FanoutPublisher) Publish(topic string, payload interface{}) error {
+	var errs []error
+	for _, p := range f.publishers {
+		if !p.IsConnected() {
+			continue
+		}
+		if err := p.Publish(topic, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsConnected es verdadero si al menos un publicador está conectado
+func (f *FanoutPublisher) IsConnected() bool {
+	for _, p := range f.publishers {
+		if p.IsConnected() {
+			return true
+		}
+	}
+	return false
+}
+
+// Connect conecta todos los publicadores
+func (f *FanoutPublisher) Connect() error {
+	var errs []error
+	for _, p := range f.publishers {
+		if err := p.Connect(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Disconnect desconecta todos los publicadores
+func (f *FanoutPublisher) Disconnect() {
+	for _, p := range f.publishers {
+		p.Disconnect()
+	}
+}
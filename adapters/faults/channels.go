@@ -0,0 +1,90 @@
+package faults
+
+import (
+	"time"
+
+	"simulador-hard/domain"
+)
+
+// channel es un canal numérico de una lectura que puede alterarse
+type channel struct {
+	name  string
+	value *float64
+}
+
+// reading es una copia modificable de la lectura
+type reading struct {
+	payload   interface{}
+	channels  []channel
+	timestamp *time.Time
+}
+
+func newReading(payload interface{}) reading {
+	switch r := payload.(type) {
+	case domain.GasReading:
+		c := r
+		return reading{&c, []channel{{"lpg", &c.LPG}, {"co", &c.CO}, {"smoke", &c.Smoke}}, &c.Timestamp}
+	case domain.ParticleReading:
+		c := r
+		return reading{&c, []channel{{"pm1_0", &c.PM10}, {"pm2_5", &c.PM25}, {"pm10", &c.PM100}}, &c.Timestamp}
+	case domain.ClimateReading:
+		c := r
+		return reading{&c, []channel{{"temperature", &c.Temperature}, {"humidity", &c.Humidity}}, &c.Timestamp}
+	case domain.SoundReading:
+		c := r
+		return reading{&c, []channel{{"level_db", &c.LevelDB}}, &c.Timestamp}
+	case domain.CO2Reading:
+		c := r
+		return reading{&c, []channel{{"co2", &c.CO2}}, &c.Timestamp}
+	case domain.FlameReading:
+		c := r
+		return reading{&c, nil, &c.Timestamp}
+	case domain.MotionReading:
+		c := r
+		return reading{&c, []channel{{"intensity", &c.Intensity}}, &c.Timestamp}
+	case domain.CameraReading:
+		c := r
+		return reading{&c, nil, &c.Timestamp}
+	case domain.CameraStreamReading:
+		c := r
+		return reading{&c, nil, &c.Timestamp}
+	}
+	return reading{payload: payload}
+}
+
+// value retorna la lectura modificada con su tipo original
+func (r reading) value() interface{} {
+	switch c := r.payload.(type) {
+	case *domain.GasReading:
+		return *c
+	case *domain.ParticleReading:
+		return *c
+	case *domain.ClimateReading:
+		return *c
+	case *domain.SoundReading:
+		return *c
+	case *domain.CO2Reading:
+		return *c
+	case *domain.FlameReading:
+		return *c
+	case *domain.MotionReading:
+		return *c
+	case *domain.CameraReading:
+		return *c
+	case *domain.CameraStreamReading:
+		return *c
+	}
+	return r.payload
+}
+
+func (r reading) selected(field string) []channel {
+	if field == "" {
+		return r.channels
+	}
+	for _, ch := range r.channels {
+		if ch.name == field {
+			return []channel{ch}
+		}
+	}
+	return nil
+}
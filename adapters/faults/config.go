@@ -0,0 +1,26 @@
+package faults
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LoadRules lee un archivo JSON con un arreglo de reglas y las registra
+func (in *Injector) LoadRules(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("faults: %s: %w", path, err)
+	}
+	for _, r := range rules {
+		if _, err := in.Add(r); err != nil {
+			return fmt.Errorf("faults: %s: %w", path, err)
+		}
+	}
+	return nil
+}
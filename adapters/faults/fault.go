@@ -0,0 +1,102 @@
+package faults
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Kind es el modo de falla de un sensor
+type Kind string
+
+const (
+	KindStuck           Kind = "stuck"            // el valor queda fijo (Value o el último leído)
+	KindDrift           Kind = "drift"            // se suma Rate unidades por segundo desde la activación
+	KindNoise           Kind = "noise"            // ruido gaussiano extra con StdDev
+	KindDropout         Kind = "dropout"          // se pierden lecturas con Probability
+	KindOutOfRange      Kind = "out_of_range"     // se reporta Value, fuera del rango físico
+	KindNaN             Kind = "nan"              // NaN con Probability (se serializa como null)
+	KindNegative        Kind = "negative"         // valores negativos imposibles
+	KindFrozenTimestamp Kind = "frozen_timestamp" // el timestamp no avanza
+	KindDead            Kind = "dead"             // el sensor deja de publicar
+)
+
+// Modos de activación
+const (
+	TriggerAPI         = "api"         // activa desde que se agrega, durante Duration
+	TriggerSchedule    = "schedule"    // activa At después del arranque, durante Duration
+	TriggerProbability = "probability" // cada lectura activa con Probability, durante Duration
+)
+
+// Fault describe el efecto sobre las lecturas. Field limita la falla a un canal
+// (lpg, co, smoke, pm1_0, pm2_5, pm10, temperature, humidity, level_db, co2,
+// intensity); vacío afecta a todos.
+type Fault struct {
+	Kind        Kind     `json:"kind"`
+	Field       string   `json:"field,omitempty"`
+	Value       *float64 `json:"value,omitempty"`
+	Rate        float64  `json:"rate,omitempty"`
+	StdDev      float64  `json:"stddev,omitempty"`
+	Probability float64  `json:"probability,omitempty"`
+}
+
+// Trigger define cuándo está activa la falla. Duration cero significa
+// permanente (o una sola lectura para TriggerProbability).
+type Trigger struct {
+	Mode        string   `json:"mode,omitempty"`
+	At          Duration `json:"at,omitempty"`
+	Duration    Duration `json:"duration,omitempty"`
+	Probability float64  `json:"probability,omitempty"`
+}
+
+// Rule asocia una falla a los sensores que coinciden con Sensor, un filtro con
+// comodines MQTT sobre el topic sin el prefijo vigiltech/sensors/ (mesa1/gas, +/particles)
+type Rule struct {
+	ID      string  `json:"id,omitempty"`
+	Sensor  string  `json:"sensor"`
+	Fault   Fault   `json:"fault"`
+	Trigger Trigger `json:"trigger"`
+}
+
+// Validate revisa que la regla sea aplicable
+func (r Rule) Validate() error {
+	if r.Sensor == "" {
+		return fmt.Errorf("faults: sensor vacío")
+	}
+	switch r.Fault.Kind {
+	case KindStuck, KindDrift, KindNoise, KindDropout, KindOutOfRange,
+		KindNaN, KindNegative, KindFrozenTimestamp, KindDead:
+	default:
+		return fmt.Errorf("faults: modo de falla desconocido %q", r.Fault.Kind)
+	}
+	switch r.Trigger.Mode {
+	case "", TriggerAPI, TriggerSchedule:
+	case TriggerProbability:
+		if r.Trigger.Probability <= 0 || r.Trigger.Probability > 1 {
+			return fmt.Errorf("faults: probabilidad de activación fuera de (0, 1]")
+		}
+	default:
+		return fmt.Errorf("faults: activación desconocida %q", r.Trigger.Mode)
+	}
+	return nil
+}
+
+// Duration es un time.Duration que se escribe en JSON como "30s" o "5m"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duración inválida %s: se espera un texto como \"30s\"", data)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
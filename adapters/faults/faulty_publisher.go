@@ -0,0 +1,42 @@
+package faults
+
+import (
+	"time"
+
+	"simulador-hard/ports"
+)
+
+// FaultyPublisher envuelve un publicador y le entrega las lecturas ya alteradas
+type FaultyPublisher struct {
+	next     ports.DataPublisher
+	injector *Injector
+}
+
+// NewFaultyPublisher crea un decorador de fallas sobre next
+func NewFaultyPublisher(next ports.DataPublisher, injector *Injector) *FaultyPublisher {
+	return &FaultyPublisher{next: next, injector: injector}
+}
+
+// Publish aplica las fallas activas y publica si la lectura no se descartó
+func (p *FaultyPublisher) Publish(topic string, payload interface{}) error {
+	payload, ok := p.injector.Apply(topic, payload, time.Now())
+	if !ok {
+		return nil
+	}
+	return p.next.Publish(topic, payload)
+}
+
+// IsConnected delega en el publicador interno
+func (p *FaultyPublisher) IsConnected() bool {
+	return p.next.IsConnected()
+}
+
+// Connect delega en el publicador interno
+func (p *FaultyPublisher) Connect() error {
+	return p.next.Connect()
+}
+
+// Disconnect delega en el publicador interno
+func (p *FaultyPublisher) Disconnect() {
+	p.next.Disconnect()
+}
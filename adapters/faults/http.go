@@ -0,0 +1,57 @@
+package faults
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Handler expone el inyector para activar fallas en caliente:
+//
+//	GET    /faults          lista las reglas y dónde están activas
+//	POST   /faults          agrega una regla (JSON de Rule), responde con su ID
+//	DELETE /faults/<id>     elimina una regla
+//	DELETE /faults          elimina todas
+//
+// Ejemplo: curl -X POST localhost:9100/faults -d '{"sensor":"mesa1/gas","fault":{"kind":"stuck"},"trigger":{"duration":"1m"}}'
+func Handler(injector *Injector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/faults"), "/")
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, injector.Rules())
+
+		case http.MethodPost:
+			var rule Rule
+			if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			id, err := injector.Add(rule)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			writeJSON(w, http.StatusCreated, map[string]string{"id": id})
+
+		case http.MethodDelete:
+			if id == "" {
+				injector.Clear()
+			} else if !injector.Remove(id) {
+				http.Error(w, "regla no encontrada", http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,283 @@
+package faults
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+// SensorTopicPrefix se quita del topic para obtener el nombre del sensor
+const SensorTopicPrefix = "vigiltech/sensors/"
+
+const (
+	defaultDropoutProbability = 0.5
+	defaultOutOfRangeValue    = 99999
+)
+
+// Injector aplica las reglas de falla a las lecturas de cada sensor
+type Injector struct {
+	mu      sync.Mutex
+	started time.Time
+	rules   map[string]*rule
+	nextID  int
+	logger  *slog.Logger
+}
+
+type rule struct {
+	Rule
+	added  time.Time
+	states map[string]*sensorState
+}
+
+// sensorState es la activación de una regla en un sensor concreto
+type sensorState struct {
+	activeFrom  time.Time
+	activeUntil time.Time // cero: sin fin
+	active      bool
+	stuck       map[string]float64
+	frozen      time.Time
+}
+
+// RuleStatus describe una regla y los sensores donde está activa
+type RuleStatus struct {
+	Rule
+	ActiveSensors []string `json:"active_sensors"`
+}
+
+// NewInjector crea un inyector; los horarios se cuentan desde ahora
+func NewInjector() *Injector {
+	return &Injector{
+		started: time.Now(),
+		rules:   make(map[string]*rule),
+		logger:  logging.For("faults"),
+	}
+}
+
+// Add registra una regla y retorna su ID
+func (in *Injector) Add(r Rule) (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if r.ID == "" {
+		in.nextID++
+		r.ID = fmt.Sprintf("fault-%d", in.nextID)
+	}
+	if _, exists := in.rules[r.ID]; exists {
+		return "", fmt.Errorf("faults: ya existe la regla %q", r.ID)
+	}
+	in.rules[r.ID] = &rule{Rule: r, added: time.Now(), states: make(map[string]*sensorState)}
+
+	in.logger.Info("falla registrada", "id", r.ID, "sensor", r.Sensor, "kind", r.Fault.Kind, "trigger", r.Trigger.Mode)
+	return r.ID, nil
+}
+
+// Remove elimina una regla; las lecturas siguientes vuelven a ser normales
+func (in *Injector) Remove(id string) bool {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if _, ok := in.rules[id]; !ok {
+		return false
+	}
+	delete(in.rules, id)
+	in.logger.Info("falla eliminada", "id", id)
+	return true
+}
+
+// Clear elimina todas las reglas
+func (in *Injector) Clear() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.rules = make(map[string]*rule)
+}
+
+// Rules lista las reglas ordenadas por ID
+func (in *Injector) Rules() []RuleStatus {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	statuses := make([]RuleStatus, 0, len(in.rules))
+	for _, r := range in.rules {
+		status := RuleStatus{Rule: r.Rule, ActiveSensors: []string{}}
+		for sensor, st := range r.states {
+			if st.active {
+				status.ActiveSensors = append(status.ActiveSensors, sensor)
+			}
+		}
+		sort.Strings(status.ActiveSensors)
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+// Apply altera la lectura según las reglas activas de su sensor.
+// Retorna false si la lectura debe descartarse.
+func (in *Injector) Apply(topic string, payload interface{}, now time.Time) (interface{}, bool) {
+	if !strings.HasPrefix(topic, SensorTopicPrefix) {
+		return payload, true
+	}
+	sensor := strings.TrimPrefix(topic, SensorTopicPrefix)
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if len(in.rules) == 0 {
+		return payload, true
+	}
+
+	ids := make([]string, 0, len(in.rules))
+	for id := range in.rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	r := newReading(payload)
+	for _, id := range ids {
+		rl := in.rules[id]
+		if !domain.MatchTopic(rl.Sensor, sensor) {
+			continue
+		}
+		st := in.activate(rl, sensor, now)
+		if !st.active {
+			continue
+		}
+		if !in.apply(rl.Fault, st, r, now) {
+			return nil, false
+		}
+	}
+	return r.value(), true
+}
+
+// activate evalúa el disparador de la regla para el sensor
+func (in *Injector) activate(rl *rule, sensor string, now time.Time) *sensorState {
+	st, ok := rl.states[sensor]
+	if !ok {
+		st = &sensorState{}
+		rl.states[sensor] = st
+	}
+
+	wasActive := st.active
+	duration := time.Duration(rl.Trigger.Duration)
+
+	switch rl.Trigger.Mode {
+	case TriggerSchedule:
+		from := in.started.Add(time.Duration(rl.Trigger.At))
+		st.activeFrom = from
+		st.active = !now.Before(from) && (duration == 0 || now.Before(from.Add(duration)))
+
+	case TriggerProbability:
+		inWindow := wasActive && (now.Before(st.activeUntil) || now.Equal(st.activeUntil))
+		if !inWindow && rand.Float64() < rl.Trigger.Probability {
+			st.activeFrom = now
+			st.activeUntil = now.Add(duration)
+			inWindow = true
+			// Cada activación por probabilidad es un episodio nuevo
+			wasActive = false
+		}
+		st.active = inWindow
+
+	default:
+		st.activeFrom = rl.added
+		st.active = duration == 0 || now.Before(rl.added.Add(duration))
+	}
+
+	if st.active && !wasActive {
+		st.stuck = nil
+		st.frozen = time.Time{}
+		in.logger.Info("falla activada", "id", rl.ID, "sensor", sensor, "kind", rl.Fault.Kind)
+	} else if !st.active && wasActive {
+		in.logger.Info("falla finalizada", "id", rl.ID, "sensor", sensor, "kind", rl.Fault.Kind)
+	}
+	return st
+}
+
+// apply modifica la lectura; retorna false si se descarta
+func (in *Injector) apply(f Fault, st *sensorState, r reading, now time.Time) bool {
+	channels := r.selected(f.Field)
+
+	switch f.Kind {
+	case KindDead:
+		return false
+
+	case KindDropout:
+		p := f.Probability
+		if p <= 0 {
+			p = defaultDropoutProbability
+		}
+		return rand.Float64() >= p
+
+	case KindStuck:
+		if st.stuck == nil {
+			st.stuck = make(map[string]float64)
+			for _, ch := range channels {
+				st.stuck[ch.name] = *ch.value
+				if f.Value != nil {
+					st.stuck[ch.name] = *f.Value
+				}
+			}
+		}
+		for _, ch := range channels {
+			if v, ok := st.stuck[ch.name]; ok {
+				*ch.value = v
+			}
+		}
+
+	case KindDrift:
+		offset := f.Rate * now.Sub(st.activeFrom).Seconds()
+		for _, ch := range channels {
+			*ch.value += offset
+		}
+
+	case KindNoise:
+		for _, ch := range channels {
+			*ch.value += f.StdDev * rand.NormFloat64()
+		}
+
+	case KindOutOfRange:
+		v := float64(defaultOutOfRangeValue)
+		if f.Value != nil {
+			v = *f.Value
+		}
+		for _, ch := range channels {
+			*ch.value = v
+		}
+
+	case KindNaN:
+		for _, ch := range channels {
+			if f.Probability <= 0 || rand.Float64() < f.Probability {
+				*ch.value = math.NaN()
+			}
+		}
+
+	case KindNegative:
+		for _, ch := range channels {
+			if f.Value != nil {
+				*ch.value = *f.Value
+			} else {
+				*ch.value = -math.Abs(*ch.value) - 1
+			}
+		}
+
+	case KindFrozenTimestamp:
+		if r.timestamp != nil {
+			if st.frozen.IsZero() {
+				st.frozen = *r.timestamp
+			}
+			*r.timestamp = st.frozen
+		}
+	}
+	return true
+}
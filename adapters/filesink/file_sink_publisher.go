@@ -0,0 +1,197 @@
+package filesink
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+// Formatos de salida soportados
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// Config define el destino y la política de rotación del sink
+type Config struct {
+	Dir      string
+	Format   string
+	MaxBytes int64         // 0 desactiva la rotación por tamaño
+	MaxAge   time.Duration // 0 desactiva la rotación por tiempo
+	Compress bool          // comprime con gzip los archivos rotados
+}
+
+// jsonlRecord es cada línea del archivo JSONL
+type jsonlRecord struct {
+	Topic     string      `json:"topic"`
+	Timestamp time.Time   `json:"timestamp"`
+	Payload   interface{} `json:"payload"`
+}
+
+// FileSinkPublisher implementa un publicador que graba los mensajes en disco
+type FileSinkPublisher struct {
+	cfg    Config
+	mu     sync.Mutex
+	files  map[string]*rotatingFile
+	gzipWG sync.WaitGroup
+	open   bool
+	logger *slog.Logger
+}
+
+// NewFileSinkPublisher crea un nuevo sink de archivos
+func NewFileSinkPublisher(cfg Config) *FileSinkPublisher {
+	return &FileSinkPublisher{
+		cfg:    cfg,
+		files:  make(map[string]*rotatingFile),
+		logger: logging.For("filesink"),
+	}
+}
+
+// Connect prepara el directorio de salida
+func (p *FileSinkPublisher) Connect() error {
+	if p.cfg.Format != FormatJSONL && p.cfg.Format != FormatCSV {
+		return fmt.Errorf("filesink: formato desconocido %q", p.cfg.Format)
+	}
+	if err := os.MkdirAll(p.cfg.Dir, 0o755); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.open = true
+	p.mu.Unlock()
+
+	p.logger.Info("grabando lecturas", "format", p.cfg.Format, "dir", p.cfg.Dir)
+	return nil
+}
+
+// Publish graba un mensaje en el archivo que le corresponde
+func (p *FileSinkPublisher) Publish(topic string, payload interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.open {
+		return nil
+	}
+
+	// Las tramas crudas (por ejemplo PMS5003) no son lecturas
+	if _, raw := payload.([]byte); raw {
+		return nil
+	}
+
+	if p.cfg.Format == FormatCSV {
+		return p.writeCSV(topic, payload)
+	}
+	return p.writeJSONL(topic, payload)
+}
+
+// IsConnected indica si el sink está aceptando mensajes
+func (p *FileSinkPublisher) IsConnected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.open
+}
+
+// Disconnect cierra los archivos y espera las compresiones pendientes
+func (p *FileSinkPublisher) Disconnect() {
+	p.mu.Lock()
+	p.open = false
+	for name, f := range p.files {
+		if err := f.Close(); err != nil {
+			p.logger.Error("error cerrando archivo", "file", name, "error", err)
+		}
+	}
+	p.files = make(map[string]*rotatingFile)
+	p.mu.Unlock()
+
+	p.gzipWG.Wait()
+	p.logger.Info("grabación cerrada")
+}
+
+func (p *FileSinkPublisher) writeJSONL(topic string, payload interface{}) error {
+	data, err := domain.MarshalReading(jsonlRecord{
+		Topic:     topic,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	})
+	if err != nil {
+		p.logger.Error("error serializando payload", "topic", topic, "error", err)
+		return err
+	}
+
+	f := p.file("readings.jsonl", nil)
+	return f.Write(append(data, '\n'))
+}
+
+func (p *FileSinkPublisher) writeCSV(topic string, payload interface{}) error {
+	record, ok := domain.ToRecord(payload)
+	if !ok {
+		p.logger.Debug("lectura sin tabla asociada", "topic", topic, "type", fmt.Sprintf("%T", payload))
+		return nil
+	}
+
+	header, err := csvLine(record.Columns)
+	if err != nil {
+		return err
+	}
+
+	fields := make([]string, len(record.Values))
+	for i, v := range record.Values {
+		fields[i] = formatCSVValue(v)
+	}
+	line, err := csvLine(fields)
+	if err != nil {
+		return err
+	}
+
+	f := p.file(record.Table+".csv", header)
+	return f.Write(line)
+}
+
+func (p *FileSinkPublisher) file(name string, header []byte) *rotatingFile {
+	f, ok := p.files[name]
+	if !ok {
+		f = newRotatingFile(filepath.Join(p.cfg.Dir, name), p.cfg, header, &p.gzipWG, p.logger)
+		p.files[name] = f
+	}
+	return f
+}
+
+func csvLine(fields []string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func formatCSVValue(v interface{}) string {
+	switch val := v.(type) {
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case float64:
+		// NaN e infinitos quedan como campo vacío
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return ""
+		}
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case int:
+		return strconv.Itoa(val)
+	case string:
+		return val
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,167 @@
+package filesink
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatingFile escribe en un archivo activo y lo rota por tamaño o antigüedad
+type rotatingFile struct {
+	path     string
+	maxBytes int64
+	maxAge   time.Duration
+	compress bool
+	header   []byte
+	gzipWG   *sync.WaitGroup
+	logger   *slog.Logger
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func newRotatingFile(path string, cfg Config, header []byte, gzipWG *sync.WaitGroup, logger *slog.Logger) *rotatingFile {
+	return &rotatingFile{
+		path:     path,
+		maxBytes: cfg.MaxBytes,
+		maxAge:   cfg.MaxAge,
+		compress: cfg.Compress,
+		header:   header,
+		gzipWG:   gzipWG,
+		logger:   logger,
+	}
+}
+
+// Write agrega una línea, rotando antes si el archivo superó sus límites
+func (f *rotatingFile) Write(line []byte) error {
+	if f.file != nil && f.shouldRotate(int64(len(line))) {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	return err
+}
+
+// Close cierra el archivo activo sin rotarlo
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) shouldRotate(next int64) bool {
+	if f.maxBytes > 0 && f.size > 0 && f.size+next > f.maxBytes {
+		return true
+	}
+	return f.maxAge > 0 && time.Since(f.openedAt) >= f.maxAge
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+
+	// El encabezado solo se escribe en archivos nuevos
+	if f.size == 0 && len(f.header) > 0 {
+		n, err := f.file.Write(f.header)
+		f.size += int64(n)
+		return err
+	}
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	rotated := rotatedPath(f.path, time.Now())
+	if err := os.Rename(f.path, rotated); err != nil {
+		return err
+	}
+
+	if f.compress {
+		f.gzipWG.Add(1)
+		go func() {
+			defer f.gzipWG.Done()
+			if err := gzipFile(rotated); err != nil {
+				f.logger.Error("error comprimiendo archivo rotado", "file", rotated, "error", err)
+			}
+		}()
+	}
+	return nil
+}
+
+// rotatedPath genera un nombre con marca de tiempo que no pise rotaciones previas
+func rotatedPath(path string, now time.Time) string {
+	ext := filepath.Ext(path)
+	base := fmt.Sprintf("%s-%s", strings.TrimSuffix(path, ext), now.Format("20060102T150405.000"))
+
+	candidate := base + ext
+	for i := 1; exists(candidate) || exists(candidate+".gz"); i++ {
+		candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	return candidate
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// gzipFile comprime un archivo rotado y elimina el original
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(path)
+	if _, err := io.Copy(zw, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,230 @@
+package grpcapi
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"simulador-hard/adapters/grpcapi/simulatorpb"
+	"simulador-hard/domain"
+)
+
+// toReading convierte un payload publicado en el mensaje protobuf del stream
+func toReading(topic string, payload interface{}) (*simulatorpb.Reading, bool) {
+	reading := &simulatorpb.Reading{
+		Topic:       topic,
+		Device:      deviceFromTopic(topic),
+		PublishedAt: timestamppb.New(time.Now()),
+	}
+
+	switch r := payload.(type) {
+	case domain.GasReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_GAS
+		reading.Payload = &simulatorpb.Reading_Gas{Gas: toGas(r)}
+	case domain.ParticleReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_PARTICLE
+		reading.Payload = &simulatorpb.Reading_Particle{Particle: toParticle(r)}
+	case domain.MotionReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_MOTION
+		reading.Payload = &simulatorpb.Reading_Motion{Motion: toMotion(r)}
+	case domain.CameraReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_CAMERA
+		reading.Payload = &simulatorpb.Reading_Camera{Camera: toCamera(r)}
+	case domain.CameraStreamReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_CAMERA_STREAM
+		reading.Payload = &simulatorpb.Reading_CameraStream{CameraStream: toCameraStream(r)}
+	case domain.ClimateReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_CLIMATE
+		reading.Payload = &simulatorpb.Reading_Climate{Climate: toClimate(r)}
+	case domain.FlameReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_FLAME
+		reading.Payload = &simulatorpb.Reading_Flame{Flame: toFlame(r)}
+	case domain.SoundReading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_SOUND
+		reading.Payload = &simulatorpb.Reading_Sound{Sound: toSound(r)}
+	case domain.CO2Reading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_CO2
+		reading.Payload = &simulatorpb.Reading_Co2{Co2: toCO2(r)}
+	case domain.PMS5003Reading:
+		reading.SensorType = simulatorpb.SensorType_SENSOR_TYPE_PMS5003
+		reading.Payload = &simulatorpb.Reading_Pms5003{Pms5003: toPMS5003(r)}
+	default:
+		return nil, false
+	}
+	return reading, true
+}
+
+// deviceFromTopic extrae "mesa1" o "usb" de vigiltech/sensors/<device>/<sensor>
+func deviceFromTopic(topic string) string {
+	levels := strings.Split(topic, "/")
+	if len(levels) >= 3 {
+		return levels[2]
+	}
+	return ""
+}
+
+func toGas(r domain.GasReading) *simulatorpb.GasReading {
+	return &simulatorpb.GasReading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		Lpg:       r.LPG,
+		Co:        r.CO,
+		Smoke:     r.Smoke,
+		Timestamp: timestamppb.New(r.Timestamp),
+	}
+}
+
+func toParticle(r domain.ParticleReading) *simulatorpb.ParticleReading {
+	return &simulatorpb.ParticleReading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		Pm1:       r.PM10,
+		Pm2_5:     r.PM25,
+		Pm10:      r.PM100,
+		Timestamp: timestamppb.New(r.Timestamp),
+	}
+}
+
+func toMotion(r domain.MotionReading) *simulatorpb.MotionReading {
+	return &simulatorpb.MotionReading{
+		Id:             r.ID,
+		SensorId:       r.SensorID,
+		SystemId:       int32(r.SystemID),
+		MotionDetected: r.MotionDetected,
+		Intensity:      r.Intensity,
+		Timestamp:      timestamppb.New(r.Timestamp),
+		Metadata:       r.Metadata,
+	}
+}
+
+func toCamera(r domain.CameraReading) *simulatorpb.CameraReading {
+	return &simulatorpb.CameraReading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		ImagePath: r.ImagePath,
+		MotionId:  r.MotionID,
+		LatencyMs: int32(r.LatencyMs),
+		Timestamp: timestamppb.New(r.Timestamp),
+		Metadata:  r.Metadata,
+	}
+}
+
+func toCameraStream(r domain.CameraStreamReading) *simulatorpb.CameraStreamReading {
+	return &simulatorpb.CameraStreamReading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		ImagePath: r.ImagePath,
+		LatencyMs: int32(r.LatencyMs),
+		Timestamp: timestamppb.New(r.Timestamp),
+	}
+}
+
+func toClimate(r domain.ClimateReading) *simulatorpb.ClimateReading {
+	return &simulatorpb.ClimateReading{
+		Id:          r.ID,
+		SensorId:    r.SensorID,
+		SystemId:    int32(r.SystemID),
+		Temperature: r.Temperature,
+		Humidity:    r.Humidity,
+		Timestamp:   timestamppb.New(r.Timestamp),
+	}
+}
+
+func toFlame(r domain.FlameReading) *simulatorpb.FlameReading {
+	return &simulatorpb.FlameReading{
+		Id:            r.ID,
+		SensorId:      r.SensorID,
+		SystemId:      int32(r.SystemID),
+		FlameDetected: r.FlameDetected,
+		IrLevel:       int32(r.IRLevel),
+		Timestamp:     timestamppb.New(r.Timestamp),
+	}
+}
+
+func toSound(r domain.SoundReading) *simulatorpb.SoundReading {
+	return &simulatorpb.SoundReading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		LevelDb:   r.LevelDB,
+		Timestamp: timestamppb.New(r.Timestamp),
+	}
+}
+
+func toCO2(r domain.CO2Reading) *simulatorpb.CO2Reading {
+	return &simulatorpb.CO2Reading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		Co2:       r.CO2,
+		Warming:   r.Warming,
+		Timestamp: timestamppb.New(r.Timestamp),
+	}
+}
+
+func toPMS5003(r domain.PMS5003Reading) *simulatorpb.PMS5003Reading {
+	return &simulatorpb.PMS5003Reading{
+		Id:        r.ID,
+		SensorId:  r.SensorID,
+		SystemId:  int32(r.SystemID),
+		Pm1Cf1:    int32(r.PM1CF1),
+		Pm2_5Cf1:  int32(r.PM25CF1),
+		Pm10Cf1:   int32(r.PM10CF1),
+		Pm1Atm:    int32(r.PM1Atm),
+		Pm2_5Atm:  int32(r.PM25Atm),
+		Pm10Atm:   int32(r.PM10Atm),
+		Gt_0_3Um:  int32(r.Count03),
+		Gt_0_5Um:  int32(r.Count05),
+		Gt_1Um:    int32(r.Count10),
+		Gt_2_5Um:  int32(r.Count25),
+		Gt_5Um:    int32(r.Count50),
+		Gt_10Um:   int32(r.Count100),
+		Version:   int32(r.Version),
+		ErrorCode: int32(r.ErrorCode),
+		Timestamp: timestamppb.New(r.Timestamp),
+	}
+}
+
+func toSnapshot(state *domain.SystemState) *simulatorpb.Snapshot {
+	snapshot := &simulatorpb.Snapshot{
+		MqttConnected: state.MQTTConnected,
+		TakenAt:       timestamppb.New(time.Now()),
+	}
+
+	mesas := make([]int, 0, len(state.ESP32States))
+	for mesaID := range state.ESP32States {
+		mesas = append(mesas, mesaID)
+	}
+	sort.Ints(mesas)
+	for _, mesaID := range mesas {
+		esp := state.ESP32States[mesaID]
+		snapshot.Esp32 = append(snapshot.Esp32, &simulatorpb.ESP32Snapshot{
+			MesaId:       int32(esp.MesaID),
+			LastGas:      toGas(esp.LastGas),
+			LastParticle: toParticle(esp.LastParticle),
+		})
+	}
+
+	if state.USBState != nil {
+		snapshot.Usb = &simulatorpb.USBSnapshot{
+			LastMotion:       toMotion(state.USBState.LastMotion),
+			LastCamera:       toCamera(state.USBState.LastCamera),
+			LastCameraStream: toCameraStream(state.USBState.LastCameraStream),
+		}
+	}
+	return snapshot
+}
+
+func toDevice(info domain.DeviceInfo) *simulatorpb.Device {
+	return &simulatorpb.Device{
+		Id:      info.ID,
+		Kind:    info.Kind,
+		Running: info.Running,
+	}
+}
@@ -0,0 +1,241 @@
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=simulador-hard --go-grpc_out=../.. --go-grpc_opt=module=simulador-hard vigiltech/simulator/v1/simulator.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"simulador-hard/adapters/grpcapi/simulatorpb"
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+	"simulador-hard/ports"
+)
+
+const subscriberBuffer = 256
+
+// SimulatorServer implementa el servicio gRPC y recibe las lecturas como publicador
+type SimulatorServer struct {
+	simulatorpb.UnimplementedSimulatorServiceServer
+
+	addr        string
+	server      *grpc.Server
+	mu          sync.RWMutex
+	controller  ports.DeviceController
+	subscribers map[*subscriber]struct{}
+	running     bool
+	logger      *slog.Logger
+}
+
+type subscriber struct {
+	devices     map[string]bool
+	sensorTypes map[simulatorpb.SensorType]bool
+	readings    chan *simulatorpb.Reading
+}
+
+// NewSimulatorServer crea un nuevo servidor gRPC
+func NewSimulatorServer(addr string) *SimulatorServer {
+	return &SimulatorServer{
+		addr:        addr,
+		subscribers: make(map[*subscriber]struct{}),
+		logger:      logging.For("grpc"),
+	}
+}
+
+// SetController asigna el servicio que atiende las consultas y el control de dispositivos
+func (s *SimulatorServer) SetController(controller ports.DeviceController) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.controller = controller
+}
+
+// Connect abre el puerto y empieza a atender RPCs
+func (s *SimulatorServer) Connect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("grpc listen %s: %w", s.addr, err)
+	}
+
+	s.server = grpc.NewServer()
+	simulatorpb.RegisterSimulatorServiceServer(s.server, s)
+	s.running = true
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.logger.Error("error del servidor gRPC", "error", err)
+		}
+	}()
+
+	s.logger.Info("API gRPC escuchando", "addr", listener.Addr().String())
+	return nil
+}
+
+// Publish reenvía la lectura a los streams suscritos
+func (s *SimulatorServer) Publish(topic string, payload interface{}) error {
+	reading, ok := toReading(topic, payload)
+	if !ok {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subscribers {
+		if !sub.matches(reading) {
+			continue
+		}
+		select {
+		case sub.readings <- reading:
+		default:
+			// Cliente lento: se descarta para no bloquear los simuladores
+		}
+	}
+	return nil
+}
+
+// IsConnected indica si el servidor está atendiendo RPCs
+func (s *SimulatorServer) IsConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// Disconnect cierra los streams abiertos y detiene el servidor
+func (s *SimulatorServer) Disconnect() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	server := s.server
+	s.mu.Unlock()
+
+	server.Stop()
+	s.logger.Info("API gRPC detenida")
+}
+
+// StreamReadings envía las lecturas que coinciden con el filtro hasta que el cliente cancele
+func (s *SimulatorServer) StreamReadings(req *simulatorpb.StreamReadingsRequest, stream grpc.ServerStreamingServer[simulatorpb.Reading]) error {
+	sub := &subscriber{
+		devices:     make(map[string]bool),
+		sensorTypes: make(map[simulatorpb.SensorType]bool),
+		readings:    make(chan *simulatorpb.Reading, subscriberBuffer),
+	}
+	for _, device := range req.GetDevices() {
+		sub.devices[device] = true
+	}
+	for _, sensorType := range req.GetSensorTypes() {
+		sub.sensorTypes[sensorType] = true
+	}
+
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, sub)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case reading := <-sub.readings:
+			if err := stream.Send(reading); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetSnapshot retorna la última lectura de cada sensor
+func (s *SimulatorServer) GetSnapshot(ctx context.Context, req *simulatorpb.GetSnapshotRequest) (*simulatorpb.Snapshot, error) {
+	controller, err := s.getController()
+	if err != nil {
+		return nil, err
+	}
+	return toSnapshot(controller.Snapshot()), nil
+}
+
+// ListDevices lista los dispositivos simulados
+func (s *SimulatorServer) ListDevices(ctx context.Context, req *simulatorpb.ListDevicesRequest) (*simulatorpb.ListDevicesResponse, error) {
+	controller, err := s.getController()
+	if err != nil {
+		return nil, err
+	}
+	resp := &simulatorpb.ListDevicesResponse{}
+	for _, info := range controller.ListDevices() {
+		resp.Devices = append(resp.Devices, toDevice(info))
+	}
+	return resp, nil
+}
+
+// StartDevice arranca un dispositivo
+func (s *SimulatorServer) StartDevice(ctx context.Context, req *simulatorpb.DeviceRequest) (*simulatorpb.Device, error) {
+	controller, err := s.getController()
+	if err != nil {
+		return nil, err
+	}
+	info, err := controller.StartDevice(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toDevice(info), nil
+}
+
+// StopDevice detiene un dispositivo
+func (s *SimulatorServer) StopDevice(ctx context.Context, req *simulatorpb.DeviceRequest) (*simulatorpb.Device, error) {
+	controller, err := s.getController()
+	if err != nil {
+		return nil, err
+	}
+	info, err := controller.StopDevice(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toDevice(info), nil
+}
+
+func (s *SimulatorServer) getController() (ports.DeviceController, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.controller == nil {
+		return nil, status.Error(codes.Unavailable, "simulador no inicializado")
+	}
+	return s.controller, nil
+}
+
+func (sub *subscriber) matches(reading *simulatorpb.Reading) bool {
+	if len(sub.devices) > 0 && !sub.devices[reading.GetDevice()] {
+		return false
+	}
+	if len(sub.sensorTypes) > 0 && !sub.sensorTypes[reading.GetSensorType()] {
+		return false
+	}
+	return true
+}
+
+func toStatus(err error) error {
+	if errors.Is(err, domain.ErrDeviceNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
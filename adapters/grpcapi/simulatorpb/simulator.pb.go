@@ -0,0 +1,2085 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: vigiltech/simulator/v1/simulator.proto
+
+package simulatorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SensorType int32
+
+const (
+	SensorType_SENSOR_TYPE_UNSPECIFIED   SensorType = 0
+	SensorType_SENSOR_TYPE_GAS           SensorType = 1
+	SensorType_SENSOR_TYPE_PARTICLE      SensorType = 2
+	SensorType_SENSOR_TYPE_MOTION        SensorType = 3
+	SensorType_SENSOR_TYPE_CAMERA        SensorType = 4
+	SensorType_SENSOR_TYPE_CAMERA_STREAM SensorType = 5
+	SensorType_SENSOR_TYPE_CLIMATE       SensorType = 6
+	SensorType_SENSOR_TYPE_FLAME         SensorType = 7
+	SensorType_SENSOR_TYPE_SOUND         SensorType = 8
+	SensorType_SENSOR_TYPE_CO2           SensorType = 9
+	SensorType_SENSOR_TYPE_PMS5003       SensorType = 10
+)
+
+// Enum value maps for SensorType.
+var (
+	SensorType_name = map[int32]string{
+		0:  "SENSOR_TYPE_UNSPECIFIED",
+		1:  "SENSOR_TYPE_GAS",
+		2:  "SENSOR_TYPE_PARTICLE",
+		3:  "SENSOR_TYPE_MOTION",
+		4:  "SENSOR_TYPE_CAMERA",
+		5:  "SENSOR_TYPE_CAMERA_STREAM",
+		6:  "SENSOR_TYPE_CLIMATE",
+		7:  "SENSOR_TYPE_FLAME",
+		8:  "SENSOR_TYPE_SOUND",
+		9:  "SENSOR_TYPE_CO2",
+		10: "SENSOR_TYPE_PMS5003",
+	}
+	SensorType_value = map[string]int32{
+		"SENSOR_TYPE_UNSPECIFIED":   0,
+		"SENSOR_TYPE_GAS":           1,
+		"SENSOR_TYPE_PARTICLE":      2,
+		"SENSOR_TYPE_MOTION":        3,
+		"SENSOR_TYPE_CAMERA":        4,
+		"SENSOR_TYPE_CAMERA_STREAM": 5,
+		"SENSOR_TYPE_CLIMATE":       6,
+		"SENSOR_TYPE_FLAME":         7,
+		"SENSOR_TYPE_SOUND":         8,
+		"SENSOR_TYPE_CO2":           9,
+		"SENSOR_TYPE_PMS5003":       10,
+	}
+)
+
+func (x SensorType) Enum() *SensorType {
+	p := new(SensorType)
+	*p = x
+	return p
+}
+
+func (x SensorType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SensorType) Descriptor() protoreflect.EnumDescriptor {
+	return file_vigiltech_simulator_v1_simulator_proto_enumTypes[0].Descriptor()
+}
+
+func (SensorType) Type() protoreflect.EnumType {
+	return &file_vigiltech_simulator_v1_simulator_proto_enumTypes[0]
+}
+
+func (x SensorType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SensorType.Descriptor instead.
+func (SensorType) EnumDescriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{0}
+}
+
+// gas_sensor (id, timestamp, lpg, co, smoke, system_id)
+type GasReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Lpg           float64                `protobuf:"fixed64,4,opt,name=lpg,proto3" json:"lpg,omitempty"`
+	Co            float64                `protobuf:"fixed64,5,opt,name=co,proto3" json:"co,omitempty"`
+	Smoke         float64                `protobuf:"fixed64,6,opt,name=smoke,proto3" json:"smoke,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GasReading) Reset() {
+	*x = GasReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GasReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GasReading) ProtoMessage() {}
+
+func (x *GasReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GasReading.ProtoReflect.Descriptor instead.
+func (*GasReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GasReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GasReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *GasReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *GasReading) GetLpg() float64 {
+	if x != nil {
+		return x.Lpg
+	}
+	return 0
+}
+
+func (x *GasReading) GetCo() float64 {
+	if x != nil {
+		return x.Co
+	}
+	return 0
+}
+
+func (x *GasReading) GetSmoke() float64 {
+	if x != nil {
+		return x.Smoke
+	}
+	return 0
+}
+
+func (x *GasReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// particle_sensor (id, timestamp, pm1_0, pm2_5, pm10, system_id)
+type ParticleReading struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	// pm1 corresponde a la columna pm1_0; se nombra así porque el nombre
+	// JSON por defecto de pm1_0 ("pm10") choca con el de pm10.
+	Pm1           float64                `protobuf:"fixed64,4,opt,name=pm1,proto3" json:"pm1,omitempty"`
+	Pm2_5         float64                `protobuf:"fixed64,5,opt,name=pm2_5,json=pm25,proto3" json:"pm2_5,omitempty"`
+	Pm10          float64                `protobuf:"fixed64,6,opt,name=pm10,proto3" json:"pm10,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticleReading) Reset() {
+	*x = ParticleReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticleReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticleReading) ProtoMessage() {}
+
+func (x *ParticleReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticleReading.ProtoReflect.Descriptor instead.
+func (*ParticleReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParticleReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ParticleReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *ParticleReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *ParticleReading) GetPm1() float64 {
+	if x != nil {
+		return x.Pm1
+	}
+	return 0
+}
+
+func (x *ParticleReading) GetPm2_5() float64 {
+	if x != nil {
+		return x.Pm2_5
+	}
+	return 0
+}
+
+func (x *ParticleReading) GetPm10() float64 {
+	if x != nil {
+		return x.Pm10
+	}
+	return 0
+}
+
+func (x *ParticleReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// motion_sensors (id, timestamp, motion_detected, intensity, system_id)
+type MotionReading struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId       string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId       int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	MotionDetected bool                   `protobuf:"varint,4,opt,name=motion_detected,json=motionDetected,proto3" json:"motion_detected,omitempty"`
+	Intensity      float64                `protobuf:"fixed64,5,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata       map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MotionReading) Reset() {
+	*x = MotionReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MotionReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MotionReading) ProtoMessage() {}
+
+func (x *MotionReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MotionReading.ProtoReflect.Descriptor instead.
+func (*MotionReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MotionReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MotionReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *MotionReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *MotionReading) GetMotionDetected() bool {
+	if x != nil {
+		return x.MotionDetected
+	}
+	return false
+}
+
+func (x *MotionReading) GetIntensity() float64 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+func (x *MotionReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *MotionReading) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// camera_capture (id, timestamp, image_path, motion_id, latency_ms, system_id)
+type CameraReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	ImagePath     string                 `protobuf:"bytes,4,opt,name=image_path,json=imagePath,proto3" json:"image_path,omitempty"`
+	MotionId      string                 `protobuf:"bytes,5,opt,name=motion_id,json=motionId,proto3" json:"motion_id,omitempty"`
+	LatencyMs     int32                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CameraReading) Reset() {
+	*x = CameraReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CameraReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CameraReading) ProtoMessage() {}
+
+func (x *CameraReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CameraReading.ProtoReflect.Descriptor instead.
+func (*CameraReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CameraReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CameraReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *CameraReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *CameraReading) GetImagePath() string {
+	if x != nil {
+		return x.ImagePath
+	}
+	return ""
+}
+
+func (x *CameraReading) GetMotionId() string {
+	if x != nil {
+		return x.MotionId
+	}
+	return ""
+}
+
+func (x *CameraReading) GetLatencyMs() int32 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *CameraReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CameraReading) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// camera_stream (id, timestamp, image_path, system_id, latency_ms)
+type CameraStreamReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	ImagePath     string                 `protobuf:"bytes,4,opt,name=image_path,json=imagePath,proto3" json:"image_path,omitempty"`
+	LatencyMs     int32                  `protobuf:"varint,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CameraStreamReading) Reset() {
+	*x = CameraStreamReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CameraStreamReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CameraStreamReading) ProtoMessage() {}
+
+func (x *CameraStreamReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CameraStreamReading.ProtoReflect.Descriptor instead.
+func (*CameraStreamReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CameraStreamReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CameraStreamReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *CameraStreamReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *CameraStreamReading) GetImagePath() string {
+	if x != nil {
+		return x.ImagePath
+	}
+	return ""
+}
+
+func (x *CameraStreamReading) GetLatencyMs() int32 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *CameraStreamReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// climate_sensor (id, timestamp, temperature, humidity, system_id)
+type ClimateReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Temperature   float64                `protobuf:"fixed64,4,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity      float64                `protobuf:"fixed64,5,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClimateReading) Reset() {
+	*x = ClimateReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClimateReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateReading) ProtoMessage() {}
+
+func (x *ClimateReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateReading.ProtoReflect.Descriptor instead.
+func (*ClimateReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClimateReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ClimateReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *ClimateReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// flame_sensor (id, timestamp, flame_detected, ir_level, system_id)
+type FlameReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	FlameDetected bool                   `protobuf:"varint,4,opt,name=flame_detected,json=flameDetected,proto3" json:"flame_detected,omitempty"`
+	IrLevel       int32                  `protobuf:"varint,5,opt,name=ir_level,json=irLevel,proto3" json:"ir_level,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlameReading) Reset() {
+	*x = FlameReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlameReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlameReading) ProtoMessage() {}
+
+func (x *FlameReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlameReading.ProtoReflect.Descriptor instead.
+func (*FlameReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FlameReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FlameReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *FlameReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *FlameReading) GetFlameDetected() bool {
+	if x != nil {
+		return x.FlameDetected
+	}
+	return false
+}
+
+func (x *FlameReading) GetIrLevel() int32 {
+	if x != nil {
+		return x.IrLevel
+	}
+	return 0
+}
+
+func (x *FlameReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// sound_sensor (id, timestamp, level_db, system_id)
+type SoundReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	LevelDb       float64                `protobuf:"fixed64,4,opt,name=level_db,json=levelDb,proto3" json:"level_db,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SoundReading) Reset() {
+	*x = SoundReading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SoundReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoundReading) ProtoMessage() {}
+
+func (x *SoundReading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoundReading.ProtoReflect.Descriptor instead.
+func (*SoundReading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SoundReading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SoundReading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *SoundReading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *SoundReading) GetLevelDb() float64 {
+	if x != nil {
+		return x.LevelDb
+	}
+	return 0
+}
+
+func (x *SoundReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// co2_sensor (id, timestamp, co2, warming, system_id)
+type CO2Reading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId      string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId      int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Co2           float64                `protobuf:"fixed64,4,opt,name=co2,proto3" json:"co2,omitempty"`
+	Warming       bool                   `protobuf:"varint,5,opt,name=warming,proto3" json:"warming,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CO2Reading) Reset() {
+	*x = CO2Reading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CO2Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CO2Reading) ProtoMessage() {}
+
+func (x *CO2Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CO2Reading.ProtoReflect.Descriptor instead.
+func (*CO2Reading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CO2Reading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CO2Reading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *CO2Reading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *CO2Reading) GetCo2() float64 {
+	if x != nil {
+		return x.Co2
+	}
+	return 0
+}
+
+func (x *CO2Reading) GetWarming() bool {
+	if x != nil {
+		return x.Warming
+	}
+	return false
+}
+
+func (x *CO2Reading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// Lectura extendida del PMS5003 con todos los campos de la trama; no tiene tabla.
+// Como en ParticleReading, pm1_* corresponde a pm1_0_* y gt_1um y gt_5um a
+// gt_1_0um y gt_5_0um, para que sus nombres JSON no choquen con pm10_* y gt_10um.
+type PMS5003Reading struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SensorId string                 `protobuf:"bytes,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	SystemId int32                  `protobuf:"varint,3,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Pm1Cf1   int32                  `protobuf:"varint,4,opt,name=pm1_cf1,json=pm1Cf1,proto3" json:"pm1_cf1,omitempty"`
+	Pm2_5Cf1 int32                  `protobuf:"varint,5,opt,name=pm2_5_cf1,json=pm25Cf1,proto3" json:"pm2_5_cf1,omitempty"`
+	Pm10Cf1  int32                  `protobuf:"varint,6,opt,name=pm10_cf1,json=pm10Cf1,proto3" json:"pm10_cf1,omitempty"`
+	Pm1Atm   int32                  `protobuf:"varint,7,opt,name=pm1_atm,json=pm1Atm,proto3" json:"pm1_atm,omitempty"`
+	Pm2_5Atm int32                  `protobuf:"varint,8,opt,name=pm2_5_atm,json=pm25Atm,proto3" json:"pm2_5_atm,omitempty"`
+	Pm10Atm  int32                  `protobuf:"varint,9,opt,name=pm10_atm,json=pm10Atm,proto3" json:"pm10_atm,omitempty"`
+	// Partículas mayores que cada tamaño por 0.1 L de aire
+	Gt_0_3Um      int32                  `protobuf:"varint,10,opt,name=gt_0_3um,json=gt03um,proto3" json:"gt_0_3um,omitempty"`
+	Gt_0_5Um      int32                  `protobuf:"varint,11,opt,name=gt_0_5um,json=gt05um,proto3" json:"gt_0_5um,omitempty"`
+	Gt_1Um        int32                  `protobuf:"varint,12,opt,name=gt_1um,json=gt1um,proto3" json:"gt_1um,omitempty"`
+	Gt_2_5Um      int32                  `protobuf:"varint,13,opt,name=gt_2_5um,json=gt25um,proto3" json:"gt_2_5um,omitempty"`
+	Gt_5Um        int32                  `protobuf:"varint,14,opt,name=gt_5um,json=gt5um,proto3" json:"gt_5um,omitempty"`
+	Gt_10Um       int32                  `protobuf:"varint,15,opt,name=gt_10um,json=gt10um,proto3" json:"gt_10um,omitempty"`
+	Version       int32                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	ErrorCode     int32                  `protobuf:"varint,17,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PMS5003Reading) Reset() {
+	*x = PMS5003Reading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PMS5003Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PMS5003Reading) ProtoMessage() {}
+
+func (x *PMS5003Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PMS5003Reading.ProtoReflect.Descriptor instead.
+func (*PMS5003Reading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PMS5003Reading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PMS5003Reading) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *PMS5003Reading) GetSystemId() int32 {
+	if x != nil {
+		return x.SystemId
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetPm1Cf1() int32 {
+	if x != nil {
+		return x.Pm1Cf1
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetPm2_5Cf1() int32 {
+	if x != nil {
+		return x.Pm2_5Cf1
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetPm10Cf1() int32 {
+	if x != nil {
+		return x.Pm10Cf1
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetPm1Atm() int32 {
+	if x != nil {
+		return x.Pm1Atm
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetPm2_5Atm() int32 {
+	if x != nil {
+		return x.Pm2_5Atm
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetPm10Atm() int32 {
+	if x != nil {
+		return x.Pm10Atm
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetGt_0_3Um() int32 {
+	if x != nil {
+		return x.Gt_0_3Um
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetGt_0_5Um() int32 {
+	if x != nil {
+		return x.Gt_0_5Um
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetGt_1Um() int32 {
+	if x != nil {
+		return x.Gt_1Um
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetGt_2_5Um() int32 {
+	if x != nil {
+		return x.Gt_2_5Um
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetGt_5Um() int32 {
+	if x != nil {
+		return x.Gt_5Um
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetGt_10Um() int32 {
+	if x != nil {
+		return x.Gt_10Um
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *PMS5003Reading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type Reading struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Topic       string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Device      string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	SensorType  SensorType             `protobuf:"varint,3,opt,name=sensor_type,json=sensorType,proto3,enum=vigiltech.simulator.v1.SensorType" json:"sensor_type,omitempty"`
+	PublishedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Reading_Gas
+	//	*Reading_Particle
+	//	*Reading_Motion
+	//	*Reading_Camera
+	//	*Reading_CameraStream
+	//	*Reading_Climate
+	//	*Reading_Flame
+	//	*Reading_Sound
+	//	*Reading_Co2
+	//	*Reading_Pms5003
+	Payload       isReading_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reading) Reset() {
+	*x = Reading{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reading) ProtoMessage() {}
+
+func (x *Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reading.ProtoReflect.Descriptor instead.
+func (*Reading) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Reading) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Reading) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *Reading) GetSensorType() SensorType {
+	if x != nil {
+		return x.SensorType
+	}
+	return SensorType_SENSOR_TYPE_UNSPECIFIED
+}
+
+func (x *Reading) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *Reading) GetPayload() isReading_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Reading) GetGas() *GasReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Gas); ok {
+			return x.Gas
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetParticle() *ParticleReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Particle); ok {
+			return x.Particle
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetMotion() *MotionReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Motion); ok {
+			return x.Motion
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetCamera() *CameraReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Camera); ok {
+			return x.Camera
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetCameraStream() *CameraStreamReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_CameraStream); ok {
+			return x.CameraStream
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetClimate() *ClimateReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Climate); ok {
+			return x.Climate
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetFlame() *FlameReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Flame); ok {
+			return x.Flame
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetSound() *SoundReading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Sound); ok {
+			return x.Sound
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetCo2() *CO2Reading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Co2); ok {
+			return x.Co2
+		}
+	}
+	return nil
+}
+
+func (x *Reading) GetPms5003() *PMS5003Reading {
+	if x != nil {
+		if x, ok := x.Payload.(*Reading_Pms5003); ok {
+			return x.Pms5003
+		}
+	}
+	return nil
+}
+
+type isReading_Payload interface {
+	isReading_Payload()
+}
+
+type Reading_Gas struct {
+	Gas *GasReading `protobuf:"bytes,10,opt,name=gas,proto3,oneof"`
+}
+
+type Reading_Particle struct {
+	Particle *ParticleReading `protobuf:"bytes,11,opt,name=particle,proto3,oneof"`
+}
+
+type Reading_Motion struct {
+	Motion *MotionReading `protobuf:"bytes,12,opt,name=motion,proto3,oneof"`
+}
+
+type Reading_Camera struct {
+	Camera *CameraReading `protobuf:"bytes,13,opt,name=camera,proto3,oneof"`
+}
+
+type Reading_CameraStream struct {
+	CameraStream *CameraStreamReading `protobuf:"bytes,14,opt,name=camera_stream,json=cameraStream,proto3,oneof"`
+}
+
+type Reading_Climate struct {
+	Climate *ClimateReading `protobuf:"bytes,15,opt,name=climate,proto3,oneof"`
+}
+
+type Reading_Flame struct {
+	Flame *FlameReading `protobuf:"bytes,16,opt,name=flame,proto3,oneof"`
+}
+
+type Reading_Sound struct {
+	Sound *SoundReading `protobuf:"bytes,17,opt,name=sound,proto3,oneof"`
+}
+
+type Reading_Co2 struct {
+	Co2 *CO2Reading `protobuf:"bytes,18,opt,name=co2,proto3,oneof"`
+}
+
+type Reading_Pms5003 struct {
+	Pms5003 *PMS5003Reading `protobuf:"bytes,19,opt,name=pms5003,proto3,oneof"`
+}
+
+func (*Reading_Gas) isReading_Payload() {}
+
+func (*Reading_Particle) isReading_Payload() {}
+
+func (*Reading_Motion) isReading_Payload() {}
+
+func (*Reading_Camera) isReading_Payload() {}
+
+func (*Reading_CameraStream) isReading_Payload() {}
+
+func (*Reading_Climate) isReading_Payload() {}
+
+func (*Reading_Flame) isReading_Payload() {}
+
+func (*Reading_Sound) isReading_Payload() {}
+
+func (*Reading_Co2) isReading_Payload() {}
+
+func (*Reading_Pms5003) isReading_Payload() {}
+
+type StreamReadingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Dispositivos a recibir ("mesa1", "usb"...). Vacío recibe todos.
+	Devices []string `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Tipos de sensor a recibir. Vacío recibe todos.
+	SensorTypes   []SensorType `protobuf:"varint,2,rep,packed,name=sensor_types,json=sensorTypes,proto3,enum=vigiltech.simulator.v1.SensorType" json:"sensor_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamReadingsRequest) Reset() {
+	*x = StreamReadingsRequest{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamReadingsRequest) ProtoMessage() {}
+
+func (x *StreamReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamReadingsRequest.ProtoReflect.Descriptor instead.
+func (*StreamReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamReadingsRequest) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *StreamReadingsRequest) GetSensorTypes() []SensorType {
+	if x != nil {
+		return x.SensorTypes
+	}
+	return nil
+}
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{12}
+}
+
+type ESP32Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MesaId        int32                  `protobuf:"varint,1,opt,name=mesa_id,json=mesaId,proto3" json:"mesa_id,omitempty"`
+	LastGas       *GasReading            `protobuf:"bytes,2,opt,name=last_gas,json=lastGas,proto3" json:"last_gas,omitempty"`
+	LastParticle  *ParticleReading       `protobuf:"bytes,3,opt,name=last_particle,json=lastParticle,proto3" json:"last_particle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ESP32Snapshot) Reset() {
+	*x = ESP32Snapshot{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ESP32Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ESP32Snapshot) ProtoMessage() {}
+
+func (x *ESP32Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ESP32Snapshot.ProtoReflect.Descriptor instead.
+func (*ESP32Snapshot) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ESP32Snapshot) GetMesaId() int32 {
+	if x != nil {
+		return x.MesaId
+	}
+	return 0
+}
+
+func (x *ESP32Snapshot) GetLastGas() *GasReading {
+	if x != nil {
+		return x.LastGas
+	}
+	return nil
+}
+
+func (x *ESP32Snapshot) GetLastParticle() *ParticleReading {
+	if x != nil {
+		return x.LastParticle
+	}
+	return nil
+}
+
+type USBSnapshot struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	LastMotion       *MotionReading         `protobuf:"bytes,1,opt,name=last_motion,json=lastMotion,proto3" json:"last_motion,omitempty"`
+	LastCamera       *CameraReading         `protobuf:"bytes,2,opt,name=last_camera,json=lastCamera,proto3" json:"last_camera,omitempty"`
+	LastCameraStream *CameraStreamReading   `protobuf:"bytes,3,opt,name=last_camera_stream,json=lastCameraStream,proto3" json:"last_camera_stream,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *USBSnapshot) Reset() {
+	*x = USBSnapshot{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *USBSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*USBSnapshot) ProtoMessage() {}
+
+func (x *USBSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use USBSnapshot.ProtoReflect.Descriptor instead.
+func (*USBSnapshot) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *USBSnapshot) GetLastMotion() *MotionReading {
+	if x != nil {
+		return x.LastMotion
+	}
+	return nil
+}
+
+func (x *USBSnapshot) GetLastCamera() *CameraReading {
+	if x != nil {
+		return x.LastCamera
+	}
+	return nil
+}
+
+func (x *USBSnapshot) GetLastCameraStream() *CameraStreamReading {
+	if x != nil {
+		return x.LastCameraStream
+	}
+	return nil
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Esp32         []*ESP32Snapshot       `protobuf:"bytes,1,rep,name=esp32,proto3" json:"esp32,omitempty"`
+	Usb           *USBSnapshot           `protobuf:"bytes,2,opt,name=usb,proto3" json:"usb,omitempty"`
+	MqttConnected bool                   `protobuf:"varint,3,opt,name=mqtt_connected,json=mqttConnected,proto3" json:"mqtt_connected,omitempty"`
+	TakenAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=taken_at,json=takenAt,proto3" json:"taken_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Snapshot) GetEsp32() []*ESP32Snapshot {
+	if x != nil {
+		return x.Esp32
+	}
+	return nil
+}
+
+func (x *Snapshot) GetUsb() *USBSnapshot {
+	if x != nil {
+		return x.Usb
+	}
+	return nil
+}
+
+func (x *Snapshot) GetMqttConnected() bool {
+	if x != nil {
+		return x.MqttConnected
+	}
+	return false
+}
+
+func (x *Snapshot) GetTakenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TakenAt
+	}
+	return nil
+}
+
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Running       bool                   `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Device) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{17}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type DeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceRequest) Reset() {
+	*x = DeviceRequest{}
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceRequest) ProtoMessage() {}
+
+func (x *DeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vigiltech_simulator_v1_simulator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceRequest.ProtoReflect.Descriptor instead.
+func (*DeviceRequest) Descriptor() ([]byte, []int) {
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeviceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_vigiltech_simulator_v1_simulator_proto protoreflect.FileDescriptor
+
+const file_vigiltech_simulator_v1_simulator_proto_rawDesc = "" +
+	"\n" +
+	"&vigiltech/simulator/v1/simulator.proto\x12\x16vigiltech.simulator.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc8\x01\n" +
+	"\n" +
+	"GasReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x10\n" +
+	"\x03lpg\x18\x04 \x01(\x01R\x03lpg\x12\x0e\n" +
+	"\x02co\x18\x05 \x01(\x01R\x02co\x12\x14\n" +
+	"\x05smoke\x18\x06 \x01(\x01R\x05smoke\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xd0\x01\n" +
+	"\x0fParticleReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x10\n" +
+	"\x03pm1\x18\x04 \x01(\x01R\x03pm1\x12\x13\n" +
+	"\x05pm2_5\x18\x05 \x01(\x01R\x04pm25\x12\x12\n" +
+	"\x04pm10\x18\x06 \x01(\x01R\x04pm10\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xe8\x02\n" +
+	"\rMotionReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12'\n" +
+	"\x0fmotion_detected\x18\x04 \x01(\bR\x0emotionDetected\x12\x1c\n" +
+	"\tintensity\x18\x05 \x01(\x01R\tintensity\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12O\n" +
+	"\bmetadata\x18\a \x03(\v23.vigiltech.simulator.v1.MotionReading.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfc\x02\n" +
+	"\rCameraReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x1d\n" +
+	"\n" +
+	"image_path\x18\x04 \x01(\tR\timagePath\x12\x1b\n" +
+	"\tmotion_id\x18\x05 \x01(\tR\bmotionId\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x06 \x01(\x05R\tlatencyMs\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12O\n" +
+	"\bmetadata\x18\b \x03(\v23.vigiltech.simulator.v1.CameraReading.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x01\n" +
+	"\x13CameraStreamReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x1d\n" +
+	"\n" +
+	"image_path\x18\x04 \x01(\tR\timagePath\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x05R\tlatencyMs\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xd2\x01\n" +
+	"\x0eClimateReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12 \n" +
+	"\vtemperature\x18\x04 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x05 \x01(\x01R\bhumidity\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xd4\x01\n" +
+	"\fFlameReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12%\n" +
+	"\x0eflame_detected\x18\x04 \x01(\bR\rflameDetected\x12\x19\n" +
+	"\bir_level\x18\x05 \x01(\x05R\airLevel\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xad\x01\n" +
+	"\fSoundReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x19\n" +
+	"\blevel_db\x18\x04 \x01(\x01R\alevelDb\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xbc\x01\n" +
+	"\n" +
+	"CO2Reading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x10\n" +
+	"\x03co2\x18\x04 \x01(\x01R\x03co2\x12\x18\n" +
+	"\awarming\x18\x05 \x01(\bR\awarming\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x82\x04\n" +
+	"\x0ePMS5003Reading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsensor_id\x18\x02 \x01(\tR\bsensorId\x12\x1b\n" +
+	"\tsystem_id\x18\x03 \x01(\x05R\bsystemId\x12\x17\n" +
+	"\apm1_cf1\x18\x04 \x01(\x05R\x06pm1Cf1\x12\x1a\n" +
+	"\tpm2_5_cf1\x18\x05 \x01(\x05R\apm25Cf1\x12\x19\n" +
+	"\bpm10_cf1\x18\x06 \x01(\x05R\apm10Cf1\x12\x17\n" +
+	"\apm1_atm\x18\a \x01(\x05R\x06pm1Atm\x12\x1a\n" +
+	"\tpm2_5_atm\x18\b \x01(\x05R\apm25Atm\x12\x19\n" +
+	"\bpm10_atm\x18\t \x01(\x05R\apm10Atm\x12\x18\n" +
+	"\bgt_0_3um\x18\n" +
+	" \x01(\x05R\x06gt03um\x12\x18\n" +
+	"\bgt_0_5um\x18\v \x01(\x05R\x06gt05um\x12\x15\n" +
+	"\x06gt_1um\x18\f \x01(\x05R\x05gt1um\x12\x18\n" +
+	"\bgt_2_5um\x18\r \x01(\x05R\x06gt25um\x12\x15\n" +
+	"\x06gt_5um\x18\x0e \x01(\x05R\x05gt5um\x12\x17\n" +
+	"\agt_10um\x18\x0f \x01(\x05R\x06gt10um\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x05R\aversion\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x11 \x01(\x05R\terrorCode\x128\n" +
+	"\ttimestamp\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xd7\x06\n" +
+	"\aReading\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12C\n" +
+	"\vsensor_type\x18\x03 \x01(\x0e2\".vigiltech.simulator.v1.SensorTypeR\n" +
+	"sensorType\x12=\n" +
+	"\fpublished_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x126\n" +
+	"\x03gas\x18\n" +
+	" \x01(\v2\".vigiltech.simulator.v1.GasReadingH\x00R\x03gas\x12E\n" +
+	"\bparticle\x18\v \x01(\v2'.vigiltech.simulator.v1.ParticleReadingH\x00R\bparticle\x12?\n" +
+	"\x06motion\x18\f \x01(\v2%.vigiltech.simulator.v1.MotionReadingH\x00R\x06motion\x12?\n" +
+	"\x06camera\x18\r \x01(\v2%.vigiltech.simulator.v1.CameraReadingH\x00R\x06camera\x12R\n" +
+	"\rcamera_stream\x18\x0e \x01(\v2+.vigiltech.simulator.v1.CameraStreamReadingH\x00R\fcameraStream\x12B\n" +
+	"\aclimate\x18\x0f \x01(\v2&.vigiltech.simulator.v1.ClimateReadingH\x00R\aclimate\x12<\n" +
+	"\x05flame\x18\x10 \x01(\v2$.vigiltech.simulator.v1.FlameReadingH\x00R\x05flame\x12<\n" +
+	"\x05sound\x18\x11 \x01(\v2$.vigiltech.simulator.v1.SoundReadingH\x00R\x05sound\x126\n" +
+	"\x03co2\x18\x12 \x01(\v2\".vigiltech.simulator.v1.CO2ReadingH\x00R\x03co2\x12B\n" +
+	"\apms5003\x18\x13 \x01(\v2&.vigiltech.simulator.v1.PMS5003ReadingH\x00R\apms5003B\t\n" +
+	"\apayload\"x\n" +
+	"\x15StreamReadingsRequest\x12\x18\n" +
+	"\adevices\x18\x01 \x03(\tR\adevices\x12E\n" +
+	"\fsensor_types\x18\x02 \x03(\x0e2\".vigiltech.simulator.v1.SensorTypeR\vsensorTypes\"\x14\n" +
+	"\x12GetSnapshotRequest\"\xb5\x01\n" +
+	"\rESP32Snapshot\x12\x17\n" +
+	"\amesa_id\x18\x01 \x01(\x05R\x06mesaId\x12=\n" +
+	"\blast_gas\x18\x02 \x01(\v2\".vigiltech.simulator.v1.GasReadingR\alastGas\x12L\n" +
+	"\rlast_particle\x18\x03 \x01(\v2'.vigiltech.simulator.v1.ParticleReadingR\flastParticle\"\xf8\x01\n" +
+	"\vUSBSnapshot\x12F\n" +
+	"\vlast_motion\x18\x01 \x01(\v2%.vigiltech.simulator.v1.MotionReadingR\n" +
+	"lastMotion\x12F\n" +
+	"\vlast_camera\x18\x02 \x01(\v2%.vigiltech.simulator.v1.CameraReadingR\n" +
+	"lastCamera\x12Y\n" +
+	"\x12last_camera_stream\x18\x03 \x01(\v2+.vigiltech.simulator.v1.CameraStreamReadingR\x10lastCameraStream\"\xdc\x01\n" +
+	"\bSnapshot\x12;\n" +
+	"\x05esp32\x18\x01 \x03(\v2%.vigiltech.simulator.v1.ESP32SnapshotR\x05esp32\x125\n" +
+	"\x03usb\x18\x02 \x01(\v2#.vigiltech.simulator.v1.USBSnapshotR\x03usb\x12%\n" +
+	"\x0emqtt_connected\x18\x03 \x01(\bR\rmqttConnected\x125\n" +
+	"\btaken_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\atakenAt\"F\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\bR\arunning\"\x14\n" +
+	"\x12ListDevicesRequest\"O\n" +
+	"\x13ListDevicesResponse\x128\n" +
+	"\adevices\x18\x01 \x03(\v2\x1e.vigiltech.simulator.v1.DeviceR\adevices\"\x1f\n" +
+	"\rDeviceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*\x9c\x02\n" +
+	"\n" +
+	"SensorType\x12\x1b\n" +
+	"\x17SENSOR_TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSENSOR_TYPE_GAS\x10\x01\x12\x18\n" +
+	"\x14SENSOR_TYPE_PARTICLE\x10\x02\x12\x16\n" +
+	"\x12SENSOR_TYPE_MOTION\x10\x03\x12\x16\n" +
+	"\x12SENSOR_TYPE_CAMERA\x10\x04\x12\x1d\n" +
+	"\x19SENSOR_TYPE_CAMERA_STREAM\x10\x05\x12\x17\n" +
+	"\x13SENSOR_TYPE_CLIMATE\x10\x06\x12\x15\n" +
+	"\x11SENSOR_TYPE_FLAME\x10\a\x12\x15\n" +
+	"\x11SENSOR_TYPE_SOUND\x10\b\x12\x13\n" +
+	"\x0fSENSOR_TYPE_CO2\x10\t\x12\x17\n" +
+	"\x13SENSOR_TYPE_PMS5003\x10\n" +
+	"2\xe6\x03\n" +
+	"\x10SimulatorService\x12b\n" +
+	"\x0eStreamReadings\x12-.vigiltech.simulator.v1.StreamReadingsRequest\x1a\x1f.vigiltech.simulator.v1.Reading0\x01\x12[\n" +
+	"\vGetSnapshot\x12*.vigiltech.simulator.v1.GetSnapshotRequest\x1a .vigiltech.simulator.v1.Snapshot\x12f\n" +
+	"\vListDevices\x12*.vigiltech.simulator.v1.ListDevicesRequest\x1a+.vigiltech.simulator.v1.ListDevicesResponse\x12T\n" +
+	"\vStartDevice\x12%.vigiltech.simulator.v1.DeviceRequest\x1a\x1e.vigiltech.simulator.v1.Device\x12S\n" +
+	"\n" +
+	"StopDevice\x12%.vigiltech.simulator.v1.DeviceRequest\x1a\x1e.vigiltech.simulator.v1.DeviceB9Z7simulador-hard/adapters/grpcapi/simulatorpb;simulatorpbb\x06proto3"
+
+var (
+	file_vigiltech_simulator_v1_simulator_proto_rawDescOnce sync.Once
+	file_vigiltech_simulator_v1_simulator_proto_rawDescData []byte
+)
+
+func file_vigiltech_simulator_v1_simulator_proto_rawDescGZIP() []byte {
+	file_vigiltech_simulator_v1_simulator_proto_rawDescOnce.Do(func() {
+		file_vigiltech_simulator_v1_simulator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vigiltech_simulator_v1_simulator_proto_rawDesc), len(file_vigiltech_simulator_v1_simulator_proto_rawDesc)))
+	})
+	return file_vigiltech_simulator_v1_simulator_proto_rawDescData
+}
+
+var file_vigiltech_simulator_v1_simulator_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_vigiltech_simulator_v1_simulator_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_vigiltech_simulator_v1_simulator_proto_goTypes = []any{
+	(SensorType)(0),               // 0: vigiltech.simulator.v1.SensorType
+	(*GasReading)(nil),            // 1: vigiltech.simulator.v1.GasReading
+	(*ParticleReading)(nil),       // 2: vigiltech.simulator.v1.ParticleReading
+	(*MotionReading)(nil),         // 3: vigiltech.simulator.v1.MotionReading
+	(*CameraReading)(nil),         // 4: vigiltech.simulator.v1.CameraReading
+	(*CameraStreamReading)(nil),   // 5: vigiltech.simulator.v1.CameraStreamReading
+	(*ClimateReading)(nil),        // 6: vigiltech.simulator.v1.ClimateReading
+	(*FlameReading)(nil),          // 7: vigiltech.simulator.v1.FlameReading
+	(*SoundReading)(nil),          // 8: vigiltech.simulator.v1.SoundReading
+	(*CO2Reading)(nil),            // 9: vigiltech.simulator.v1.CO2Reading
+	(*PMS5003Reading)(nil),        // 10: vigiltech.simulator.v1.PMS5003Reading
+	(*Reading)(nil),               // 11: vigiltech.simulator.v1.Reading
+	(*StreamReadingsRequest)(nil), // 12: vigiltech.simulator.v1.StreamReadingsRequest
+	(*GetSnapshotRequest)(nil),    // 13: vigiltech.simulator.v1.GetSnapshotRequest
+	(*ESP32Snapshot)(nil),         // 14: vigiltech.simulator.v1.ESP32Snapshot
+	(*USBSnapshot)(nil),           // 15: vigiltech.simulator.v1.USBSnapshot
+	(*Snapshot)(nil),              // 16: vigiltech.simulator.v1.Snapshot
+	(*Device)(nil),                // 17: vigiltech.simulator.v1.Device
+	(*ListDevicesRequest)(nil),    // 18: vigiltech.simulator.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),   // 19: vigiltech.simulator.v1.ListDevicesResponse
+	(*DeviceRequest)(nil),         // 20: vigiltech.simulator.v1.DeviceRequest
+	nil,                           // 21: vigiltech.simulator.v1.MotionReading.MetadataEntry
+	nil,                           // 22: vigiltech.simulator.v1.CameraReading.MetadataEntry
+	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
+}
+var file_vigiltech_simulator_v1_simulator_proto_depIdxs = []int32{
+	23, // 0: vigiltech.simulator.v1.GasReading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 1: vigiltech.simulator.v1.ParticleReading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 2: vigiltech.simulator.v1.MotionReading.timestamp:type_name -> google.protobuf.Timestamp
+	21, // 3: vigiltech.simulator.v1.MotionReading.metadata:type_name -> vigiltech.simulator.v1.MotionReading.MetadataEntry
+	23, // 4: vigiltech.simulator.v1.CameraReading.timestamp:type_name -> google.protobuf.Timestamp
+	22, // 5: vigiltech.simulator.v1.CameraReading.metadata:type_name -> vigiltech.simulator.v1.CameraReading.MetadataEntry
+	23, // 6: vigiltech.simulator.v1.CameraStreamReading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 7: vigiltech.simulator.v1.ClimateReading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 8: vigiltech.simulator.v1.FlameReading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 9: vigiltech.simulator.v1.SoundReading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 10: vigiltech.simulator.v1.CO2Reading.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 11: vigiltech.simulator.v1.PMS5003Reading.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 12: vigiltech.simulator.v1.Reading.sensor_type:type_name -> vigiltech.simulator.v1.SensorType
+	23, // 13: vigiltech.simulator.v1.Reading.published_at:type_name -> google.protobuf.Timestamp
+	1,  // 14: vigiltech.simulator.v1.Reading.gas:type_name -> vigiltech.simulator.v1.GasReading
+	2,  // 15: vigiltech.simulator.v1.Reading.particle:type_name -> vigiltech.simulator.v1.ParticleReading
+	3,  // 16: vigiltech.simulator.v1.Reading.motion:type_name -> vigiltech.simulator.v1.MotionReading
+	4,  // 17: vigiltech.simulator.v1.Reading.camera:type_name -> vigiltech.simulator.v1.CameraReading
+	5,  // 18: vigiltech.simulator.v1.Reading.camera_stream:type_name -> vigiltech.simulator.v1.CameraStreamReading
+	6,  // 19: vigiltech.simulator.v1.Reading.climate:type_name -> vigiltech.simulator.v1.ClimateReading
+	7,  // 20: vigiltech.simulator.v1.Reading.flame:type_name -> vigiltech.simulator.v1.FlameReading
+	8,  // 21: vigiltech.simulator.v1.Reading.sound:type_name -> vigiltech.simulator.v1.SoundReading
+	9,  // 22: vigiltech.simulator.v1.Reading.co2:type_name -> vigiltech.simulator.v1.CO2Reading
+	10, // 23: vigiltech.simulator.v1.Reading.pms5003:type_name -> vigiltech.simulator.v1.PMS5003Reading
+	0,  // 24: vigiltech.simulator.v1.StreamReadingsRequest.sensor_types:type_name -> vigiltech.simulator.v1.SensorType
+	1,  // 25: vigiltech.simulator.v1.ESP32Snapshot.last_gas:type_name -> vigiltech.simulator.v1.GasReading
+	2,  // 26: vigiltech.simulator.v1.ESP32Snapshot.last_particle:type_name -> vigiltech.simulator.v1.ParticleReading
+	3,  // 27: vigiltech.simulator.v1.USBSnapshot.last_motion:type_name -> vigiltech.simulator.v1.MotionReading
+	4,  // 28: vigiltech.simulator.v1.USBSnapshot.last_camera:type_name -> vigiltech.simulator.v1.CameraReading
+	5,  // 29: vigiltech.simulator.v1.USBSnapshot.last_camera_stream:type_name -> vigiltech.simulator.v1.CameraStreamReading
+	14, // 30: vigiltech.simulator.v1.Snapshot.esp32:type_name -> vigiltech.simulator.v1.ESP32Snapshot
+	15, // 31: vigiltech.simulator.v1.Snapshot.usb:type_name -> vigiltech.simulator.v1.USBSnapshot
+	23, // 32: vigiltech.simulator.v1.Snapshot.taken_at:type_name -> google.protobuf.Timestamp
+	17, // 33: vigiltech.simulator.v1.ListDevicesResponse.devices:type_name -> vigiltech.simulator.v1.Device
+	12, // 34: vigiltech.simulator.v1.SimulatorService.StreamReadings:input_type -> vigiltech.simulator.v1.StreamReadingsRequest
+	13, // 35: vigiltech.simulator.v1.SimulatorService.GetSnapshot:input_type -> vigiltech.simulator.v1.GetSnapshotRequest
+	18, // 36: vigiltech.simulator.v1.SimulatorService.ListDevices:input_type -> vigiltech.simulator.v1.ListDevicesRequest
+	20, // 37: vigiltech.simulator.v1.SimulatorService.StartDevice:input_type -> vigiltech.simulator.v1.DeviceRequest
+	20, // 38: vigiltech.simulator.v1.SimulatorService.StopDevice:input_type -> vigiltech.simulator.v1.DeviceRequest
+	11, // 39: vigiltech.simulator.v1.SimulatorService.StreamReadings:output_type -> vigiltech.simulator.v1.Reading
+	16, // 40: vigiltech.simulator.v1.SimulatorService.GetSnapshot:output_type -> vigiltech.simulator.v1.Snapshot
+	19, // 41: vigiltech.simulator.v1.SimulatorService.ListDevices:output_type -> vigiltech.simulator.v1.ListDevicesResponse
+	17, // 42: vigiltech.simulator.v1.SimulatorService.StartDevice:output_type -> vigiltech.simulator.v1.Device
+	17, // 43: vigiltech.simulator.v1.SimulatorService.StopDevice:output_type -> vigiltech.simulator.v1.Device
+	39, // [39:44] is the sub-list for method output_type
+	34, // [34:39] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_vigiltech_simulator_v1_simulator_proto_init() }
+func file_vigiltech_simulator_v1_simulator_proto_init() {
+	if File_vigiltech_simulator_v1_simulator_proto != nil {
+		return
+	}
+	file_vigiltech_simulator_v1_simulator_proto_msgTypes[10].OneofWrappers = []any{
+		(*Reading_Gas)(nil),
+		(*Reading_Particle)(nil),
+		(*Reading_Motion)(nil),
+		(*Reading_Camera)(nil),
+		(*Reading_CameraStream)(nil),
+		(*Reading_Climate)(nil),
+		(*Reading_Flame)(nil),
+		(*Reading_Sound)(nil),
+		(*Reading_Co2)(nil),
+		(*Reading_Pms5003)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vigiltech_simulator_v1_simulator_proto_rawDesc), len(file_vigiltech_simulator_v1_simulator_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vigiltech_simulator_v1_simulator_proto_goTypes,
+		DependencyIndexes: file_vigiltech_simulator_v1_simulator_proto_depIdxs,
+		EnumInfos:         file_vigiltech_simulator_v1_simulator_proto_enumTypes,
+		MessageInfos:      file_vigiltech_simulator_v1_simulator_proto_msgTypes,
+	}.Build()
+	File_vigiltech_simulator_v1_simulator_proto = out.File
+	file_vigiltech_simulator_v1_simulator_proto_goTypes = nil
+	file_vigiltech_simulator_v1_simulator_proto_depIdxs = nil
+}
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: vigiltech/simulator/v1/simulator.proto
+
+package simulatorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SimulatorService_StreamReadings_FullMethodName = "/vigiltech.simulator.v1.SimulatorService/StreamReadings"
+	SimulatorService_GetSnapshot_FullMethodName    = "/vigiltech.simulator.v1.SimulatorService/GetSnapshot"
+	SimulatorService_ListDevices_FullMethodName    = "/vigiltech.simulator.v1.SimulatorService/ListDevices"
+	SimulatorService_StartDevice_FullMethodName    = "/vigiltech.simulator.v1.SimulatorService/StartDevice"
+	SimulatorService_StopDevice_FullMethodName     = "/vigiltech.simulator.v1.SimulatorService/StopDevice"
+)
+
+// SimulatorServiceClient is the client API for SimulatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SimulatorService expone las lecturas y el control de los dispositivos simulados.
+type SimulatorServiceClient interface {
+	// StreamReadings envía cada lectura publicada que coincida con el filtro.
+	StreamReadings(ctx context.Context, in *StreamReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Reading], error)
+	// GetSnapshot retorna la última lectura de cada sensor.
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	// ListDevices lista los dispositivos y si están en ejecución.
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// StartDevice arranca un dispositivo detenido ("mesa1".."mesaN" o "usb").
+	StartDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// StopDevice detiene un dispositivo en ejecución.
+	StopDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
+}
+
+type simulatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulatorServiceClient(cc grpc.ClientConnInterface) SimulatorServiceClient {
+	return &simulatorServiceClient{cc}
+}
+
+func (c *simulatorServiceClient) StreamReadings(ctx context.Context, in *StreamReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Reading], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulatorService_ServiceDesc.Streams[0], SimulatorService_StreamReadings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamReadingsRequest, Reading]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulatorService_StreamReadingsClient = grpc.ServerStreamingClient[Reading]
+
+func (c *simulatorServiceClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, SimulatorService_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, SimulatorService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorServiceClient) StartDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, SimulatorService_StartDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorServiceClient) StopDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, SimulatorService_StopDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulatorServiceServer is the server API for SimulatorService service.
+// All implementations must embed UnimplementedSimulatorServiceServer
+// for forward compatibility.
+//
+// SimulatorService expone las lecturas y el control de los dispositivos simulados.
+type SimulatorServiceServer interface {
+	// StreamReadings envía cada lectura publicada que coincida con el filtro.
+	StreamReadings(*StreamReadingsRequest, grpc.ServerStreamingServer[Reading]) error
+	// GetSnapshot retorna la última lectura de cada sensor.
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error)
+	// ListDevices lista los dispositivos y si están en ejecución.
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// StartDevice arranca un dispositivo detenido ("mesa1".."mesaN" o "usb").
+	StartDevice(context.Context, *DeviceRequest) (*Device, error)
+	// StopDevice detiene un dispositivo en ejecución.
+	StopDevice(context.Context, *DeviceRequest) (*Device, error)
+	mustEmbedUnimplementedSimulatorServiceServer()
+}
+
+// UnimplementedSimulatorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSimulatorServiceServer struct{}
+
+func (UnimplementedSimulatorServiceServer) StreamReadings(*StreamReadingsRequest, grpc.ServerStreamingServer[Reading]) error {
+	return status.Error(codes.Unimplemented, "method StreamReadings not implemented")
+}
+func (UnimplementedSimulatorServiceServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedSimulatorServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedSimulatorServiceServer) StartDevice(context.Context, *DeviceRequest) (*Device, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartDevice not implemented")
+}
+func (UnimplementedSimulatorServiceServer) StopDevice(context.Context, *DeviceRequest) (*Device, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopDevice not implemented")
+}
+func (UnimplementedSimulatorServiceServer) mustEmbedUnimplementedSimulatorServiceServer() {}
+func (UnimplementedSimulatorServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeSimulatorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulatorServiceServer will
+// result in compilation errors.
+type UnsafeSimulatorServiceServer interface {
+	mustEmbedUnimplementedSimulatorServiceServer()
+}
+
+func RegisterSimulatorServiceServer(s grpc.ServiceRegistrar, srv SimulatorServiceServer) {
+	// If the following call panics, it indicates UnimplementedSimulatorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SimulatorService_ServiceDesc, srv)
+}
+
+func _SimulatorService_StreamReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReadingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulatorServiceServer).StreamReadings(m, &grpc.GenericServerStream[StreamReadingsRequest, Reading]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulatorService_StreamReadingsServer = grpc.ServerStreamingServer[Reading]
+
+func _SimulatorService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulatorService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServiceServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulatorService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulatorService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulatorService_StartDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServiceServer).StartDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulatorService_StartDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServiceServer).StartDevice(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulatorService_StopDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServiceServer).StopDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulatorService_StopDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServiceServer).StopDevice(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimulatorService_ServiceDesc is the grpc.ServiceDesc for SimulatorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulatorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vigiltech.simulator.v1.SimulatorService",
+	HandlerType: (*SimulatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _SimulatorService_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _SimulatorService_ListDevices_Handler,
+		},
+		{
+			MethodName: "StartDevice",
+			Handler:    _SimulatorService_StartDevice_Handler,
+		},
+		{
+			MethodName: "StopDevice",
+			Handler:    _SimulatorService_StopDevice_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamReadings",
+			Handler:       _SimulatorService_StreamReadings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "vigiltech/simulator/v1/simulator.proto",
+}
@@ -0,0 +1,90 @@
+package hardware
+
+import (
+	"sync"
+	"time"
+
+	"simulador-hard/adapters/hardware/signal"
+)
+
+// Correlation define qué acompaña al humo en una combustión: cada ppm de humo
+// que llega a la mesa suma CO y partículas, de modo que el MQ-135 y el PMS5003
+// ven el mismo episodio
+type Correlation struct {
+	SmokeToCO   float64 // ppm de CO por ppm de humo
+	SmokeToPM25 float64 // µg/m³ de PM2.5 por ppm de humo (PM1 y PM10 según PMExposure)
+
+	// Combustion son los episodios de humo propios de cada mesa; nil si los
+	// eventos llegan solo desde el ambiente base (sala o escenario)
+	Combustion signal.Spec
+}
+
+// DefaultCorrelation produce un episodio de combustión cada ~50s por mesa,
+// como los picos de humo independientes que reemplaza
+func DefaultCorrelation() Correlation {
+	return Correlation{
+		SmokeToCO:   0.3,
+		SmokeToPM25: 0.06,
+		Combustion:  signal.Decay{Rate: 0.02, MinAmplitude: 100, MaxAmplitude: 350, Tau: 20 * time.Second},
+	}
+}
+
+// CorrelatedEnvironment es el estado compartido de cada mesa que muestrean
+// tanto el sensor de gas como el de partículas
+type CorrelatedEnvironment struct {
+	base  Environment
+	corr  Correlation
+	mu    sync.Mutex
+	mesas map[int]*combustion
+}
+
+// combustion guarda el último valor para que ambos sensores lean el mismo
+// episodio aunque muestreen en instantes distintos
+type combustion struct {
+	model signal.Model
+	last  time.Time
+	smoke float64
+}
+
+// NewCorrelatedEnvironment aplica la correlación sobre base (puede ser nil)
+func NewCorrelatedEnvironment(base Environment, corr Correlation) *CorrelatedEnvironment {
+	return &CorrelatedEnvironment{
+		base:  base,
+		corr:  corr,
+		mesas: make(map[int]*combustion),
+	}
+}
+
+// Exposure suma la combustión propia al humo del ambiente base y deriva el CO
+// y las partículas del humo total
+func (e *CorrelatedEnvironment) Exposure(mesaID int, t time.Time) Exposure {
+	var exp Exposure
+	if e.base != nil {
+		exp = e.base.Exposure(mesaID, t)
+	}
+
+	exp.Smoke += e.combustion(mesaID, t)
+	exp.CO += e.corr.SmokeToCO * exp.Smoke
+	return exp.Add(PMExposure(e.corr.SmokeToPM25 * exp.Smoke))
+}
+
+func (e *CorrelatedEnvironment) combustion(mesaID int, t time.Time) float64 {
+	if e.corr.Combustion == nil {
+		return 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	c, ok := e.mesas[mesaID]
+	if !ok {
+		c = &combustion{model: e.corr.Combustion.New()}
+		e.mesas[mesaID] = c
+	}
+	// Solo se avanza hacia adelante; una muestra atrasada ve el último valor
+	if t.After(c.last) {
+		c.smoke = c.model.Next(t)
+		c.last = t
+	}
+	return c.smoke
+}
@@ -0,0 +1,65 @@
+package hardware
+
+import (
+	"time"
+
+	"simulador-hard/adapters/hardware/room"
+)
+
+// Exposure es la concentración que el ambiente agrega a la línea base de una mesa
+type Exposure struct {
+	LPG, CO, Smoke   float64
+	PM1, PM25, PM100 float64
+}
+
+// Environment entrega la exposición de cada mesa en un instante
+type Environment interface {
+	Exposure(mesaID int, t time.Time) Exposure
+}
+
+// Proporciones de PM1.0 y PM10 respecto de PM2.5 para el humo interior
+const (
+	roomPM1Ratio  = 0.6
+	roomPM10Ratio = 1.4
+)
+
+// RoomEnvironment adapta el modelo de sala a la interfaz Environment
+type RoomEnvironment struct {
+	room *room.Room
+}
+
+// NewRoomEnvironment crea el ambiente a partir de una sala
+func NewRoomEnvironment(r *room.Room) *RoomEnvironment {
+	return &RoomEnvironment{room: r}
+}
+
+// Exposure muestrea la sala en la posición de la mesa
+func (e *RoomEnvironment) Exposure(mesaID int, t time.Time) Exposure {
+	c := e.room.SampleAll(mesaID, t)
+	exp := PMExposure(c[room.PM])
+	exp.LPG = c[room.LPG]
+	exp.CO = c[room.CO]
+	exp.Smoke = c[room.Smoke]
+	return exp
+}
+
+// PMExposure reparte un exceso de PM2.5 entre las tres fracciones
+func PMExposure(pm25 float64) Exposure {
+	return Exposure{
+		PM1:   pm25 * roomPM1Ratio,
+		PM25:  pm25,
+		PM100: pm25 * roomPM10Ratio,
+	}
+}
+
+// Add suma dos exposiciones
+func (e Exposure) Add(o Exposure) Exposure {
+	return Exposure{
+		LPG:   e.LPG + o.LPG,
+		CO:    e.CO + o.CO,
+		Smoke: e.Smoke + o.Smoke,
+		PM1:   e.PM1 + o.PM1,
+		PM25:  e.PM25 + o.PM25,
+		PM100: e.PM100 + o.PM100,
+	}
+}
@@ -2,140 +2,264 @@ package hardware
 
 import (
 	"fmt"
-	"math/rand"
+	"log/slog"
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
+	"simulador-hard/adapters/hardware/mq135"
 	"simulador-hard/domain"
+	"simulador-hard/logging"
 	"simulador-hard/ports"
 )
 
 type ESP32HardwareSimulator struct {
-	mesaID       int
-	publisher    ports.DataPublisher
-	stopChan     chan struct{}
-	mu           sync.RWMutex
-	lastGas      domain.GasReading
-	lastParticle domain.ParticleReading
+	mesaID    int
+	publisher ports.DataPublisher
+	serial    ports.DataPublisher
+	faults    FaultInjector
+	stopChan  chan struct{}
+	running   bool
+	mu        sync.RWMutex
+	sensors   []Sensor
+	last      map[string]interface{}
+	signals   *channelModels
+	env       Environment
+	logger    *slog.Logger
 }
 
 func NewESP32Simulator(mesaID int, publisher ports.DataPublisher) *ESP32HardwareSimulator {
+	sensors, _ := NewSensors(DefaultSensors)
 	return &ESP32HardwareSimulator{
 		mesaID:    mesaID,
 		publisher: publisher,
-		stopChan:  make(chan struct{}),
+		sensors:   sensors,
+		last:      make(map[string]interface{}),
+		signals:   newChannelModels(DefaultSignalConfig()),
+		logger:    logging.For("esp32").With("mesa", mesaID),
 	}
 }
 
+// SetSensors reemplaza los sensores montados; debe llamarse antes de Start y
+// antes de configurar cada sensor (SetMQ135Config, SetPMS5003Config...)
+func (s *ESP32HardwareSimulator) SetSensors(sensors []Sensor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sensors = sensors
+}
+
+// SetSerialPort conecta la salida UART opcional; debe llamarse antes de Start
+func (s *ESP32HardwareSimulator) SetSerialPort(serial ports.DataPublisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serial = serial
+}
+
+// FaultInjector altera o descarta una lectura según las fallas activas del sensor
+type FaultInjector interface {
+	Apply(topic string, payload interface{}, now time.Time) (interface{}, bool)
+}
+
+// SetFaultInjector aplica las fallas a las salidas serie (UART y trama PMS5003),
+// que no pasan por el publicador; debe llamarse antes de Start
+func (s *ESP32HardwareSimulator) SetFaultInjector(faults FaultInjector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = faults
+}
+
+// SetSignalConfig reemplaza los modelos de señal de cada canal
+func (s *ESP32HardwareSimulator) SetSignalConfig(cfg SignalConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signals = newChannelModels(cfg)
+}
+
+// SetMQ135Config cambia los parámetros eléctricos; se aplica en el próximo Start
+func (s *ESP32HardwareSimulator) SetMQ135Config(cfg mq135.Config) {
+	if m, ok := s.sensor("mq135").(*MQ135Sensor); ok {
+		m.SetConfig(cfg)
+	}
+}
+
+// SetEnvironment suma a cada lectura la exposición del ambiente compartido
+func (s *ESP32HardwareSimulator) SetEnvironment(env Environment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.env = env
+}
+
 func (s *ESP32HardwareSimulator) Start() {
-	go s.simulateGasSensor()
-	go s.simulateParticleSensor()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.stopChan = make(chan struct{})
+	s.running = true
+
+	now := time.Now()
+	for _, sensor := range s.sensors {
+		// Cada arranque es un encendido: los calefactores vuelven a precalentar
+		if p, ok := sensor.(PoweredSensor); ok {
+			p.PowerOn(now)
+		}
+		go s.runSensor(sensor, s.stopChan)
+	}
 }
 
-func (s *ESP32HardwareSimulator) simulateGasSensor() {
-	ticker := time.NewTicker(1800 * time.Millisecond)
+// runSensor muestrea un sensor en su período y publica cada lectura
+func (s *ESP32HardwareSimulator) runSensor(sensor Sensor, stop <-chan struct{}) {
+	ticker := time.NewTicker(sensor.Period())
 	defer ticker.Stop()
 
+	prefix := fmt.Sprintf("vigiltech/sensors/mesa%d/", s.mesaID)
+	topic := prefix + sensor.Topic()
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stop:
 			return
-		case <-ticker.C:
-			baseLPG := 150.0 + rand.Float64()*250
-			baseCO := 100.0 + rand.Float64()*200
-			baseSmoke := 120.0 + rand.Float64()*230
-
-			if rand.Float64() > 0.85 {
-				spikeType := rand.Intn(3)
-				spike := rand.Float64() * 400
-
-				switch spikeType {
-				case 0:
-					baseLPG += spike
-				case 1:
-					baseCO += spike
-				case 2:
-					baseSmoke += spike
-				}
-			}
-
-			reading := domain.GasReading{
-				ID:        uuid.New().String(),  // Generar UUID
-				SensorID:  fmt.Sprintf("ESP32-MESA-%d-GAS", s.mesaID),
-				SystemID:  s.mesaID,
-				LPG:       baseLPG,
-				CO:        baseCO,
-				Smoke:     baseSmoke,
-				Timestamp: time.Now(),
+		case now := <-ticker.C:
+			reading, err := sensor.Sample(s, now)
+			if err != nil {
+				s.logger.Warn("lectura fallida", "sensor", sensor.Kind(), "error", err)
+				continue
 			}
 
 			s.mu.Lock()
-			s.lastGas = reading
+			s.last[sensor.Kind()] = reading
 			s.mu.Unlock()
 
-			if s.publisher != nil && s.publisher.IsConnected() {
-				topic := fmt.Sprintf("vigiltech/sensors/mesa%d/gas", s.mesaID)
-				s.publisher.Publish(topic, reading)
+			s.logger.Debug("lectura", "sensor", sensor.Kind(), "reading", reading)
+
+			s.publish(topic, reading)
+
+			// Lo que sale por el cable serie es la lectura con las fallas del sensor
+			wire, ok := s.applyFaults(topic, reading, now)
+			if !ok {
+				continue
+			}
+			s.writeSerial(topic, wire)
+
+			if out, ok := sensor.(OutputSensor); ok {
+				for _, o := range out.Outputs(wire) {
+					s.publish(prefix+o.Topic, o.Payload)
+				}
 			}
 		}
 	}
 }
 
-func (s *ESP32HardwareSimulator) simulateParticleSensor() {
-	ticker := time.NewTicker(2200 * time.Millisecond)
-	defer ticker.Stop()
+// sensor retorna el sensor montado de un tipo, o nil
+func (s *ESP32HardwareSimulator) sensor(kind string) Sensor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sensor := range s.sensors {
+		if sensor.Kind() == kind {
+			return sensor
+		}
+	}
+	return nil
+}
 
-	for {
-		select {
-		case <-s.stopChan:
-			return
-		case <-ticker.C:
-			pm10 := 10.0 + rand.Float64()*50
-			pm25 := pm10 + 5.0 + rand.Float64()*30
-			pm100 := pm25 + 10.0 + rand.Float64()*35
-
-			if rand.Float64() > 0.8 {
-				contaminationFactor := 1.5 + rand.Float64()*1.5
-				pm10 *= contaminationFactor
-				pm25 *= contaminationFactor
-				pm100 *= contaminationFactor
-			}
+// MesaID implementa SensorHost
+func (s *ESP32HardwareSimulator) MesaID() int {
+	return s.mesaID
+}
 
-			reading := domain.ParticleReading{
-				ID:        uuid.New().String(),  // Generar UUID
-				SensorID:  fmt.Sprintf("ESP32-MESA-%d-PM", s.mesaID),
-				SystemID:  s.mesaID,
-				PM10:      pm10,
-				PM25:      pm25,
-				PM100:     pm100,
-				Timestamp: time.Now(),
-			}
+// SensorID implementa SensorHost: ESP32-MESA-<n>-<sufijo>
+func (s *ESP32HardwareSimulator) SensorID(suffix string) string {
+	return fmt.Sprintf("ESP32-MESA-%d-%s", s.mesaID, suffix)
+}
 
-			s.mu.Lock()
-			s.lastParticle = reading
-			s.mu.Unlock()
+// Exposure consulta el ambiente compartido, si hay uno
+func (s *ESP32HardwareSimulator) Exposure(t time.Time) Exposure {
+	s.mu.RLock()
+	env := s.env
+	s.mu.RUnlock()
 
-			if s.publisher != nil && s.publisher.IsConnected() {
-				topic := fmt.Sprintf("vigiltech/sensors/mesa%d/particles", s.mesaID)
-				s.publisher.Publish(topic, reading)
-			}
+	if env == nil {
+		return Exposure{}
+	}
+	return env.Exposure(s.mesaID, t)
+}
+
+// Gas implementa SensorHost con los canales de gas de la mesa
+func (s *ESP32HardwareSimulator) Gas(t time.Time, exp Exposure) (lpg, co, smoke float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signals.gas(t, exp)
+}
+
+// Ambient implementa SensorHost con la temperatura y humedad de la mesa
+func (s *ESP32HardwareSimulator) Ambient(t time.Time) (temperature, humidity float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signals.ambient(t)
+}
+
+// Particles implementa SensorHost con los canales de partículas de la mesa
+func (s *ESP32HardwareSimulator) Particles(t time.Time, exp Exposure) (pm1, pm25, pm10 float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signals.particles(t, exp)
+}
+
+// applyFaults pasa la lectura por el inyector de fallas, si hay uno
+func (s *ESP32HardwareSimulator) applyFaults(topic string, reading interface{}, now time.Time) (interface{}, bool) {
+	s.mu.RLock()
+	faults := s.faults
+	s.mu.RUnlock()
+
+	if faults == nil {
+		return reading, true
+	}
+	return faults.Apply(topic, reading, now)
+}
+
+// writeSerial envía la lectura por el UART emulado, independiente de MQTT
+func (s *ESP32HardwareSimulator) writeSerial(topic string, reading interface{}) {
+	s.mu.RLock()
+	serial := s.serial
+	s.mu.RUnlock()
+
+	if serial != nil && serial.IsConnected() {
+		if err := serial.Publish(topic, reading); err != nil {
+			s.logger.Error("error escribiendo en el puerto serie", "error", err)
+		}
+	}
+}
+
+func (s *ESP32HardwareSimulator) publish(topic string, payload interface{}) {
+	if s.publisher != nil && s.publisher.IsConnected() {
+		if err := s.publisher.Publish(topic, payload); err != nil {
+			s.logger.Error("error publicando", "topic", topic, "error", err)
 		}
 	}
 }
 
 func (s *ESP32HardwareSimulator) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
 	close(s.stopChan)
+	s.running = false
 }
 
-func (s *ESP32HardwareSimulator) GetState() interface{} {
+func (s *ESP32HardwareSimulator) IsRunning() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.running
+}
 
+func (s *ESP32HardwareSimulator) GetState() interface{} {
 	return &domain.ESP32State{
 		MesaID:       s.mesaID,
-		LastGas:      s.lastGas,
-		LastParticle: s.lastParticle,
+		LastGas:      s.GetGasReading(),
+		LastParticle: s.GetParticleReading(),
 	}
 }
 
@@ -144,13 +268,21 @@ func (s *ESP32HardwareSimulator) GetMesaID() int {
 }
 
 func (s *ESP32HardwareSimulator) GetGasReading() domain.GasReading {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.lastGas
+	r, _ := s.GetSensorReading("mq135")
+	gas, _ := r.(domain.GasReading)
+	return gas
 }
 
 func (s *ESP32HardwareSimulator) GetParticleReading() domain.ParticleReading {
+	r, _ := s.GetSensorReading("pms5003")
+	particles, _ := r.(domain.ParticleReading)
+	return particles
+}
+
+// GetSensorReading retorna la última lectura de un tipo de sensor
+func (s *ESP32HardwareSimulator) GetSensorReading(kind string) (interface{}, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.lastParticle
+	r, ok := s.last[kind]
+	return r, ok
 }
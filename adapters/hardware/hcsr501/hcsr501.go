@@ -0,0 +1,152 @@
+// Package hcsr501 modela la salida del sensor PIR HC-SR501.
+//
+// El módulo pone la salida en alto cuando el piroeléctrico ve un cambio de
+// calor y la mantiene durante el tiempo de retención (potenciómetro Tx, de
+// ~3 s a ~5 min). Con el jumper en L (disparo único) la salida baja al cumplir
+// la retención aunque siga habiendo movimiento; en H (redisparable) cada nuevo
+// disparo reinicia la retención. Al bajar, el sensor queda bloqueado ~2.5 s e
+// ignora todo disparo, por eso entre dos eventos siempre hay un hueco. El
+// potenciómetro Sx ajusta el alcance entre ~3 y ~7 m.
+package hcsr501
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Mode es la posición del jumper de disparo
+type Mode int
+
+const (
+	Repeatable Mode = iota // H: el movimiento continuo extiende la salida
+	Single                 // L: la salida dura exactamente Hold
+)
+
+func (m Mode) String() string {
+	if m == Single {
+		return "single"
+	}
+	return "repeatable"
+}
+
+// Límites físicos del módulo
+const (
+	MinHold  = 3 * time.Second
+	MaxHold  = 5 * time.Minute
+	MinRange = 3.0 // metros con Sx al mínimo
+	MaxRange = 7.0 // metros con Sx al máximo
+)
+
+// Config son los ajustes del módulo
+type Config struct {
+	Hold        time.Duration // potenciómetro Tx
+	Mode        Mode          // jumper L/H
+	Block       time.Duration // bloqueo tras bajar la salida
+	Sensitivity float64       // potenciómetro Sx, 0 (3 m) a 1 (7 m)
+	WarmUp      time.Duration // estabilización tras el encendido, sin disparos
+}
+
+// DefaultConfig es el módulo de fábrica con la retención al mínimo
+func DefaultConfig() Config {
+	return Config{
+		Hold:        5 * time.Second,
+		Mode:        Repeatable,
+		Block:       2500 * time.Millisecond,
+		Sensitivity: 0.5,
+		WarmUp:      30 * time.Second,
+	}
+}
+
+// Range retorna el alcance en metros según la sensibilidad
+func (c Config) Range() float64 {
+	s := c.Sensitivity
+	if s < 0 {
+		s = 0
+	} else if s > 1 {
+		s = 1
+	}
+	return MinRange + s*(MaxRange-MinRange)
+}
+
+// Presence es lo que hay frente al sensor en un instante
+type Presence struct {
+	Moving   bool    // una fuente de calor se mueve entre zonas del lente
+	Distance float64 // metros
+}
+
+// Sensor es el estado de la salida de un módulo
+type Sensor struct {
+	cfg          Config
+	poweredOn    time.Time
+	high         bool
+	highSince    time.Time
+	lowAt        time.Time // fin de la retención mientras está en alto
+	blockedUntil time.Time
+}
+
+// New crea el módulo encendido en poweredOn
+func New(cfg Config, poweredOn time.Time) *Sensor {
+	if cfg.Hold < MinHold {
+		cfg.Hold = MinHold
+	} else if cfg.Hold > MaxHold {
+		cfg.Hold = MaxHold
+	}
+	return &Sensor{cfg: cfg, poweredOn: poweredOn}
+}
+
+// Config retorna los ajustes efectivos (la retención queda dentro de sus límites)
+func (s *Sensor) Config() Config {
+	return s.cfg
+}
+
+// Update avanza el módulo hasta t con la presencia observada y retorna el nivel
+// de la salida. Debe llamarse a intervalos cortos (~100 ms) para que los bordes
+// tengan la resolución del hardware.
+func (s *Sensor) Update(t time.Time, p Presence) bool {
+	// Fin de la retención
+	if s.high && !t.Before(s.lowAt) {
+		s.high = false
+		s.blockedUntil = s.lowAt.Add(s.cfg.Block)
+	}
+
+	if !s.triggered(t, p) {
+		return s.high
+	}
+
+	switch {
+	case !s.high:
+		s.high = true
+		s.highSince = t
+		s.lowAt = t.Add(s.cfg.Hold)
+	case s.cfg.Mode == Repeatable:
+		s.lowAt = t.Add(s.cfg.Hold)
+	}
+	return s.high
+}
+
+// triggered indica si el piroeléctrico dispara en este paso
+func (s *Sensor) triggered(t time.Time, p Presence) bool {
+	if !p.Moving || p.Distance > s.cfg.Range() {
+		return false
+	}
+	if t.Sub(s.poweredOn) < s.cfg.WarmUp || t.Before(s.blockedUntil) {
+		return false
+	}
+	// Cerca del límite del alcance el cambio de calor es menor y dispara menos
+	return rand.Float64() < 1-0.7*p.Distance/s.cfg.Range()
+}
+
+// High indica si la salida está en alto
+func (s *Sensor) High() bool {
+	return s.high
+}
+
+// HighSince retorna cuándo subió la salida actual
+func (s *Sensor) HighSince() time.Time {
+	return s.highSince
+}
+
+// Blocked indica si el módulo está en la ventana de bloqueo en t
+func (s *Sensor) Blocked(t time.Time) bool {
+	return !s.high && t.Before(s.blockedUntil)
+}
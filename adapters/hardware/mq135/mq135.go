@@ -0,0 +1,168 @@
+// Package mq135 emula la cadena eléctrica del sensor MQ-135 conectado al ADC del ESP32:
+//
+//	Vc (5V) ── Rs ──┬── RL ── GND
+//	                └── divisor ── ADC 12 bits (Vref 3.3V)
+//
+// La resistencia del sensor sigue la curva del datasheet Rs/R0 = (ppm/A)^(1/B),
+// se corrige por temperatura y humedad y se eleva mientras el calefactor no
+// alcanza la temperatura de trabajo. Cada gas se publica en su propio canal ADC.
+//
+// El backend convierte los conteos con el camino inverso:
+//
+//	V     = adc / 4095 * Vref / DividerRatio
+//	Rs    = RL * (Vc - V) / V
+//	ratio = Rs / R0 / CorrectionFactor(T, H)
+//	ppm   = A * ratio^B
+package mq135
+
+import (
+	"math"
+	"time"
+)
+
+// Curve es el ajuste potencial ppm = A * (Rs/R0)^B de un gas
+type Curve struct {
+	A, B float64
+}
+
+// Curvas por gas. CO sale del datasheet del MQ-135; LPG y humo no aparecen en
+// él y se usan los ajustes de la familia MQ-2 como aproximación.
+var (
+	CurveLPG   = Curve{A: 574.25, B: -2.222}
+	CurveCO    = Curve{A: 605.18, B: -3.937}
+	CurveSmoke = Curve{A: 3616.1, B: -2.675}
+)
+
+// PPM convierte la relación Rs/R0 en concentración
+func (c Curve) PPM(ratio float64) float64 {
+	if ratio <= 0 {
+		return 0
+	}
+	return c.A * math.Pow(ratio, c.B)
+}
+
+// Ratio retorna la relación Rs/R0 que produce una concentración
+func (c Curve) Ratio(ppm float64) float64 {
+	if ppm <= 0 {
+		return math.Inf(1)
+	}
+	return math.Pow(ppm/c.A, 1/c.B)
+}
+
+// Coeficientes de la dependencia de temperatura y humedad del datasheet,
+// normalizados a 1 en 20°C / 33%RH
+const (
+	corrA = 0.00035
+	corrB = 0.02718
+	corrC = 1.39538
+	corrD = 0.0018
+)
+
+// CorrectionFactor es el factor por el que se multiplica Rs según el ambiente
+func CorrectionFactor(temperature, humidity float64) float64 {
+	return corrA*temperature*temperature - corrB*temperature + corrC - (humidity-33)*corrD
+}
+
+// Config son los parámetros eléctricos del módulo
+type Config struct {
+	SupplyVoltage float64       // Vc, voltios
+	LoadKOhm      float64       // RL del módulo
+	R0KOhm        float64       // Rs en 100 ppm de NH3 / aire limpio calibrado
+	ADCBits       int           // resolución del ADC del ESP32
+	ADCRef        float64       // tensión de fondo de escala del ADC
+	DividerRatio  float64       // divisor entre la salida de 5V y el pin del ADC
+	WarmUp        time.Duration // tiempo hasta que el calefactor estabiliza
+	WarmUpFactor  float64       // Rs extra al encender (1 = el doble)
+}
+
+// DefaultConfig corresponde al módulo con RL de 10k y divisor 2/3 hacia el ADC.
+// El datasheet pide 24h de precalentamiento; se acorta para la simulación.
+func DefaultConfig() Config {
+	return Config{
+		SupplyVoltage: 5.0,
+		LoadKOhm:      10,
+		R0KOhm:        76.63,
+		ADCBits:       12,
+		ADCRef:        3.3,
+		DividerRatio:  2.0 / 3.0,
+		WarmUp:        time.Minute,
+		WarmUpFactor:  2.0,
+	}
+}
+
+// Channel es la medición de un gas
+type Channel struct {
+	ADC int
+	PPM float64
+}
+
+// Sample es la salida de una medición de los tres gases
+type Sample struct {
+	LPG, CO, Smoke Channel
+	Warming        bool
+}
+
+// Sensor es un MQ-135 encendido en un instante dado
+type Sensor struct {
+	cfg       Config
+	poweredOn time.Time
+}
+
+// NewSensor enciende el calefactor en poweredOn
+func NewSensor(cfg Config, poweredOn time.Time) *Sensor {
+	return &Sensor{cfg: cfg, poweredOn: poweredOn}
+}
+
+// Warming indica si el sensor todavía no alcanzó la temperatura de trabajo
+func (s *Sensor) Warming(t time.Time) bool {
+	return t.Sub(s.poweredOn) < s.cfg.WarmUp
+}
+
+// Measure convierte las concentraciones reales en conteos ADC y ppm derivados
+func (s *Sensor) Measure(t time.Time, lpg, co, smoke, temperature, humidity float64) Sample {
+	env := CorrectionFactor(temperature, humidity) * s.warmUpFactor(t)
+	return Sample{
+		LPG:     s.channel(CurveLPG, lpg, env, temperature, humidity),
+		CO:      s.channel(CurveCO, co, env, temperature, humidity),
+		Smoke:   s.channel(CurveSmoke, smoke, env, temperature, humidity),
+		Warming: s.Warming(t),
+	}
+}
+
+func (s *Sensor) channel(curve Curve, ppm, env, temperature, humidity float64) Channel {
+	rs := s.cfg.R0KOhm * curve.Ratio(ppm) * env
+	adc := s.toADC(s.cfg.SupplyVoltage * s.cfg.LoadKOhm / (rs + s.cfg.LoadKOhm))
+	return Channel{ADC: adc, PPM: s.ToPPM(curve, adc, temperature, humidity)}
+}
+
+// ToPPM es la conversión que hace el firmware (y debe reproducir el backend)
+func (s *Sensor) ToPPM(curve Curve, adc int, temperature, humidity float64) float64 {
+	if adc <= 0 {
+		return 0
+	}
+	v := float64(adc) / float64(s.maxCount()) * s.cfg.ADCRef / s.cfg.DividerRatio
+	rs := s.cfg.LoadKOhm * (s.cfg.SupplyVoltage - v) / v
+	if rs <= 0 {
+		return curve.PPM(math.SmallestNonzeroFloat64)
+	}
+	return curve.PPM(rs / s.cfg.R0KOhm / CorrectionFactor(temperature, humidity))
+}
+
+// warmUpFactor eleva Rs al encender y decae a 1 (<1%) al final del WarmUp
+func (s *Sensor) warmUpFactor(t time.Time) float64 {
+	if s.cfg.WarmUp <= 0 {
+		return 1
+	}
+	elapsed := t.Sub(s.poweredOn).Seconds()
+	tau := s.cfg.WarmUp.Seconds() / 5
+	return 1 + s.cfg.WarmUpFactor*math.Exp(-elapsed/tau)
+}
+
+func (s *Sensor) toADC(vout float64) int {
+	counts := math.Round(vout * s.cfg.DividerRatio / s.cfg.ADCRef * float64(s.maxCount()))
+	return int(math.Max(0, math.Min(counts, float64(s.maxCount()))))
+}
+
+func (s *Sensor) maxCount() int {
+	return 1<<s.cfg.ADCBits - 1
+}
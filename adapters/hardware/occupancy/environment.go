@@ -0,0 +1,39 @@
+package occupancy
+
+import (
+	"time"
+
+	"simulador-hard/adapters/hardware"
+)
+
+// Polvo que levanta cada persona activa junto a una mesa (µg/m³); la
+// resuspensión es sobre todo de partículas gruesas
+const (
+	pm1PerPerson  = 1.0
+	pm25PerPerson = 3.0
+	pm10PerPerson = 12.0
+)
+
+// Environment suma al ambiente base las partículas que levanta la ocupación
+type Environment struct {
+	base  hardware.Environment
+	model *Model
+}
+
+// NewEnvironment envuelve base (puede ser nil) con la actividad de model
+func NewEnvironment(base hardware.Environment, model *Model) *Environment {
+	return &Environment{base: base, model: model}
+}
+
+// Exposure implementa hardware.Environment
+func (e *Environment) Exposure(mesaID int, t time.Time) hardware.Exposure {
+	var exp hardware.Exposure
+	if e.base != nil {
+		exp = e.base.Exposure(mesaID, t)
+	}
+	activity := e.model.Activity(mesaID, t)
+	exp.PM1 += pm1PerPerson * activity
+	exp.PM25 += pm25PerPerson * activity
+	exp.PM100 += pm10PerPerson * activity
+	return exp
+}
@@ -0,0 +1,357 @@
+// Package occupancy simula a las personas que usan el laboratorio: entran por
+// la puerta, caminan hasta una mesa, trabajan un rato, cambian de mesa y se
+// van, siguiendo un horario de ocupación. Los agentes alimentan al PIR USB
+// (campo de visión y distancia) y la actividad de cada mesa, que levanta
+// partículas.
+package occupancy
+
+import (
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"simulador-hard/adapters/hardware/hcsr501"
+	"simulador-hard/adapters/hardware/room"
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+// Slot es la cantidad de personas esperada entre dos horas del día
+type Slot struct {
+	From, To int // horas locales, To exclusiva
+	People   int
+}
+
+// DefaultSchedule es un día de clases: mañana, almuerzo, tarde y cierre
+var DefaultSchedule = []Slot{
+	{From: 8, To: 12, People: 6},
+	{From: 12, To: 14, People: 3},
+	{From: 14, To: 19, People: 8},
+	{From: 19, To: 21, People: 2},
+}
+
+// PIRMount es la posición y orientación del PIR USB
+type PIRMount struct {
+	At        room.Point
+	Direction float64 // radianes, 0 hacia +X
+	FOV       float64 // apertura total en radianes
+}
+
+// Config define la sala, el horario y el comportamiento de las personas
+type Config struct {
+	Width, Depth float64
+	Door         room.Point
+	Mesas        map[int]room.Point
+	PIR          PIRMount
+
+	Schedule []Slot
+	// Fixed > 0 mantiene esa cantidad de personas e ignora el horario
+	Fixed int
+
+	WalkSpeed        float64       // m/s
+	MinStay, MaxStay time.Duration // tiempo en una mesa antes de moverse
+	ArrivalRate      float64       // llegadas (o salidas) por segundo hacia el objetivo
+	LeaveProbability float64       // probabilidad de irse al terminar una estadía
+	FidgetRate       float64       // fracción del tiempo que alguien sentado se mueve
+}
+
+// DefaultConfig usa la geometría de la sala del modelo de difusión, con la
+// puerta en la pared izquierda y el PIR en la esquina mirando hacia las mesas
+func DefaultConfig(r room.Config) Config {
+	return Config{
+		Width: r.Width,
+		Depth: r.Depth,
+		Door:  room.Point{X: 0, Y: r.Depth / 2},
+		Mesas: r.Mesas,
+		PIR: PIRMount{
+			At:        room.Point{X: 0.2, Y: 0.2},
+			Direction: math.Pi / 4,
+			FOV:       110 * math.Pi / 180,
+		},
+		Schedule:         DefaultSchedule,
+		WalkSpeed:        1.2,
+		MinStay:          2 * time.Minute,
+		MaxStay:          20 * time.Minute,
+		ArrivalRate:      1.0 / 20,
+		LeaveProbability: 0.3,
+		FidgetRate:       0.25,
+	}
+}
+
+// Target retorna cuántas personas debería haber en t
+func (c Config) Target(t time.Time) int {
+	if c.Fixed > 0 {
+		return c.Fixed
+	}
+	h := t.Hour()
+	for _, s := range c.Schedule {
+		if h >= s.From && h < s.To {
+			return s.People
+		}
+	}
+	return 0
+}
+
+// Distancia a la que se considera que alguien está en una mesa
+const atMesa = 1.5
+
+// Paso máximo de la simulación
+const maxStep = 200 * time.Millisecond
+
+type agent struct {
+	id       int
+	pos      room.Point
+	target   room.Point
+	mesa     int
+	activity string
+	until    time.Time
+	moving   bool
+}
+
+// Model es el estado de la ocupación; se avanza bajo demanda hasta el instante pedido
+type Model struct {
+	cfg    Config
+	mu     sync.Mutex
+	now    time.Time
+	agents []*agent
+	nextID int
+	mesas  []int
+	pir    hcsr501.Config
+	logger *slog.Logger
+}
+
+// New crea la sala vacía en start
+func New(cfg Config, start time.Time) *Model {
+	mesas := make([]int, 0, len(cfg.Mesas))
+	for id := range cfg.Mesas {
+		mesas = append(mesas, id)
+	}
+	sort.Ints(mesas)
+	return &Model{
+		cfg:    cfg,
+		now:    start,
+		mesas:  mesas,
+		pir:    hcsr501.DefaultConfig(),
+		logger: logging.For("occupancy"),
+	}
+}
+
+// SetPIRConfig informa el alcance del PIR para el plano de la sala
+func (m *Model) SetPIRConfig(cfg hcsr501.Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pir = cfg
+}
+
+// Presence implementa hardware.PresenceSource: la persona en movimiento más
+// cercana dentro del campo de visión del PIR
+func (m *Model) Presence(t time.Time) hcsr501.Presence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance(t)
+
+	best := hcsr501.Presence{}
+	for _, a := range m.agents {
+		if !a.moving || !m.inView(a.pos) {
+			continue
+		}
+		d := distance(m.cfg.PIR.At, a.pos)
+		if !best.Moving || d < best.Distance {
+			best = hcsr501.Presence{Moving: true, Distance: d}
+		}
+	}
+	return best
+}
+
+// Activity retorna cuántas personas hay junto a una mesa en t; quien camina
+// cuenta entero y quien está sentado según FidgetRate
+func (m *Model) Activity(mesaID int, t time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance(t)
+
+	p, ok := m.cfg.Mesas[mesaID]
+	if !ok {
+		return 0
+	}
+	var n float64
+	for _, a := range m.agents {
+		if distance(p, a.pos) > atMesa {
+			continue
+		}
+		if a.activity == domain.ActivityWorking {
+			n += m.cfg.FidgetRate
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// Occupants retorna una copia de las personas en la sala
+func (m *Model) Occupants() []domain.Occupant {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance(time.Now())
+
+	out := make([]domain.Occupant, len(m.agents))
+	for i, a := range m.agents {
+		out[i] = domain.Occupant{
+			ID:       a.id,
+			Position: domain.Position{X: a.pos.X, Y: a.pos.Y},
+			Activity: a.activity,
+			Mesa:     a.mesa,
+			Moving:   a.moving,
+		}
+	}
+	return out
+}
+
+// Layout retorna la geometría de la sala y del PIR
+func (m *Model) Layout() domain.RoomLayout {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mesas := make(map[int]domain.Position, len(m.cfg.Mesas))
+	for id, p := range m.cfg.Mesas {
+		mesas[id] = domain.Position{X: p.X, Y: p.Y}
+	}
+	return domain.RoomLayout{
+		Width:        m.cfg.Width,
+		Depth:        m.cfg.Depth,
+		Door:         domain.Position{X: m.cfg.Door.X, Y: m.cfg.Door.Y},
+		Mesas:        mesas,
+		PIR:          domain.Position{X: m.cfg.PIR.At.X, Y: m.cfg.PIR.At.Y},
+		PIRDirection: m.cfg.PIR.Direction,
+		PIRFOV:       m.cfg.PIR.FOV,
+		PIRRange:     m.pir.Range(),
+	}
+}
+
+// advance simula hasta t en pasos de a lo sumo maxStep
+func (m *Model) advance(t time.Time) {
+	for m.now.Before(t) {
+		dt := t.Sub(m.now)
+		if dt > maxStep {
+			dt = maxStep
+		}
+		m.now = m.now.Add(dt)
+		m.step(dt)
+	}
+}
+
+func (m *Model) step(dt time.Duration) {
+	seconds := dt.Seconds()
+
+	present := 0
+	for _, a := range m.agents {
+		if a.activity != domain.ActivityLeaving {
+			present++
+		}
+	}
+	target := m.cfg.Target(m.now)
+	switch {
+	case present < target && rand.Float64() < m.cfg.ArrivalRate*seconds:
+		m.spawn()
+	case present > target && rand.Float64() < m.cfg.ArrivalRate*seconds:
+		m.sendHome()
+	}
+
+	kept := m.agents[:0]
+	for _, a := range m.agents {
+		if m.move(a, seconds) {
+			kept = append(kept, a)
+		}
+	}
+	m.agents = kept
+}
+
+// move avanza un agente; retorna false cuando salió por la puerta
+func (m *Model) move(a *agent, seconds float64) bool {
+	switch a.activity {
+	case domain.ActivityWorking:
+		a.moving = rand.Float64() < m.cfg.FidgetRate
+		if m.now.Before(a.until) {
+			return true
+		}
+		if rand.Float64() < m.cfg.LeaveProbability {
+			a.activity = domain.ActivityLeaving
+			a.target = m.cfg.Door
+		} else {
+			a.mesa = m.randomMesa()
+			a.target = m.seat(a.mesa)
+			a.activity = domain.ActivityWalking
+		}
+		return true
+	}
+
+	a.moving = true
+	d := distance(a.pos, a.target)
+	stride := m.cfg.WalkSpeed * seconds
+	if d > stride {
+		a.pos.X += (a.target.X - a.pos.X) / d * stride
+		a.pos.Y += (a.target.Y - a.pos.Y) / d * stride
+		return true
+	}
+
+	a.pos = a.target
+	if a.activity == domain.ActivityLeaving {
+		m.logger.Debug("persona salió", "id", a.id)
+		return false
+	}
+	a.activity = domain.ActivityWorking
+	a.until = m.now.Add(m.cfg.MinStay + time.Duration(rand.Int63n(int64(m.cfg.MaxStay-m.cfg.MinStay)+1)))
+	return true
+}
+
+func (m *Model) spawn() {
+	if len(m.mesas) == 0 {
+		return
+	}
+	m.nextID++
+	mesa := m.randomMesa()
+	m.agents = append(m.agents, &agent{
+		id:       m.nextID,
+		pos:      m.cfg.Door,
+		target:   m.seat(mesa),
+		mesa:     mesa,
+		activity: domain.ActivityEntering,
+	})
+	m.logger.Debug("persona entró", "id", m.nextID, "mesa", mesa)
+}
+
+// sendHome hace salir a alguien que esté trabajando
+func (m *Model) sendHome() {
+	for _, i := range rand.Perm(len(m.agents)) {
+		a := m.agents[i]
+		if a.activity == domain.ActivityWorking {
+			a.activity = domain.ActivityLeaving
+			a.target = m.cfg.Door
+			return
+		}
+	}
+}
+
+func (m *Model) randomMesa() int {
+	return m.mesas[rand.Intn(len(m.mesas))]
+}
+
+// seat es un lugar frente a la mesa, con algo de variación
+func (m *Model) seat(mesa int) room.Point {
+	p := m.cfg.Mesas[mesa]
+	return room.Point{X: p.X + (rand.Float64() - 0.5), Y: p.Y + 0.8}
+}
+
+// inView indica si p está dentro del cono del PIR
+func (m *Model) inView(p room.Point) bool {
+	angle := math.Atan2(p.Y-m.cfg.PIR.At.Y, p.X-m.cfg.PIR.At.X)
+	diff := math.Remainder(angle-m.cfg.PIR.Direction, 2*math.Pi)
+	return math.Abs(diff) <= m.cfg.PIR.FOV/2
+}
+
+func distance(a, b room.Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
@@ -0,0 +1,41 @@
+package pms5003
+
+import "math/rand"
+
+// Tipos de corrupción que puede sufrir una trama en el cable
+const (
+	CorruptChecksum  = "checksum"  // un byte de datos alterado sin recalcular el checksum
+	CorruptStart     = "start"     // bytes de inicio perdidos
+	CorruptTruncated = "truncated" // la trama se corta antes del final
+	CorruptNoise     = "noise"     // bytes basura antes de la trama
+)
+
+// CorruptKinds lista todas las corrupciones disponibles
+var CorruptKinds = []string{CorruptChecksum, CorruptStart, CorruptTruncated, CorruptNoise}
+
+// Corrupt retorna una copia dañada de la trama según kind
+func Corrupt(frame []byte, kind string) []byte {
+	out := append([]byte(nil), frame...)
+	switch kind {
+	case CorruptChecksum:
+		out[4+rand.Intn(26)] ^= byte(1 << rand.Intn(8))
+	case CorruptStart:
+		out[rand.Intn(2)] = byte(rand.Intn(0x42))
+	case CorruptTruncated:
+		out = out[:4+rand.Intn(FrameSize-5)]
+	case CorruptNoise:
+		noise := make([]byte, 1+rand.Intn(8))
+		for i := range noise {
+			// Sin 0x42 para que el ruido no parezca un inicio de trama
+			noise[i] = byte(rand.Intn(0x42))
+		}
+		out = append(noise, out...)
+	}
+	return out
+}
+
+// RandomCorrupt aplica una corrupción al azar y retorna su tipo
+func RandomCorrupt(frame []byte) ([]byte, string) {
+	kind := CorruptKinds[rand.Intn(len(CorruptKinds))]
+	return Corrupt(frame, kind), kind
+}
@@ -0,0 +1,99 @@
+// Package pms5003 codifica y decodifica las tramas de 32 bytes del sensor
+// Plantower PMS5003 (modo activo, 9600 8N1):
+//
+//	byte  0-1   0x42 0x4D
+//	byte  2-3   longitud = 28 (2*13 datos + 2 checksum)
+//	byte  4-9   PM1.0, PM2.5, PM10 con CF=1 (partícula estándar, µg/m³)
+//	byte 10-15  PM1.0, PM2.5, PM10 atmosféricos (µg/m³)
+//	byte 16-27  partículas > 0.3, 0.5, 1.0, 2.5, 5.0 y 10 µm en 0.1 L de aire
+//	byte 28     versión
+//	byte 29     código de error
+//	byte 30-31  checksum = suma de los bytes 0..29
+//
+// Todos los campos son big-endian de 16 bits.
+package pms5003
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	FrameSize   = 32
+	StartByte1  = 0x42
+	StartByte2  = 0x4D
+	frameLength = 28
+)
+
+// Errores de decodificación
+var (
+	ErrShortFrame  = errors.New("pms5003: trama incompleta")
+	ErrStartBytes  = errors.New("pms5003: bytes de inicio inválidos")
+	ErrFrameLength = errors.New("pms5003: longitud inválida")
+	ErrChecksum    = errors.New("pms5003: checksum inválido")
+)
+
+// Frame contiene todos los campos de datos de una trama
+type Frame struct {
+	PM1CF1, PM25CF1, PM10CF1 uint16
+	PM1Atm, PM25Atm, PM10Atm uint16
+	// Partículas mayores que 0.3, 0.5, 1.0, 2.5, 5.0 y 10 µm por 0.1 L
+	Counts    [6]uint16
+	Version   uint8
+	ErrorCode uint8
+}
+
+// Encode genera la trama de 32 bytes con su checksum
+func (f Frame) Encode() []byte {
+	buf := make([]byte, FrameSize)
+	buf[0], buf[1] = StartByte1, StartByte2
+	binary.BigEndian.PutUint16(buf[2:], frameLength)
+
+	fields := []uint16{f.PM1CF1, f.PM25CF1, f.PM10CF1, f.PM1Atm, f.PM25Atm, f.PM10Atm}
+	fields = append(fields, f.Counts[:]...)
+	for i, v := range fields {
+		binary.BigEndian.PutUint16(buf[4+2*i:], v)
+	}
+	buf[28] = f.Version
+	buf[29] = f.ErrorCode
+
+	binary.BigEndian.PutUint16(buf[30:], checksum(buf[:30]))
+	return buf
+}
+
+// Decode valida y decodifica una trama completa
+func Decode(buf []byte) (Frame, error) {
+	if len(buf) < FrameSize {
+		return Frame{}, ErrShortFrame
+	}
+	if buf[0] != StartByte1 || buf[1] != StartByte2 {
+		return Frame{}, ErrStartBytes
+	}
+	if n := binary.BigEndian.Uint16(buf[2:]); n != frameLength {
+		return Frame{}, fmt.Errorf("%w: %d", ErrFrameLength, n)
+	}
+	if want, got := checksum(buf[:30]), binary.BigEndian.Uint16(buf[30:]); want != got {
+		return Frame{}, fmt.Errorf("%w: calculado %#04x, recibido %#04x", ErrChecksum, want, got)
+	}
+
+	field := func(i int) uint16 { return binary.BigEndian.Uint16(buf[4+2*i:]) }
+	f := Frame{
+		PM1CF1: field(0), PM25CF1: field(1), PM10CF1: field(2),
+		PM1Atm: field(3), PM25Atm: field(4), PM10Atm: field(5),
+		Version:   buf[28],
+		ErrorCode: buf[29],
+	}
+	for i := range f.Counts {
+		f.Counts[i] = field(6 + i)
+	}
+	return f, nil
+}
+
+func checksum(data []byte) uint16 {
+	var sum uint16
+	for _, b := range data {
+		sum += uint16(b)
+	}
+	return sum
+}
@@ -0,0 +1,31 @@
+package pms5003
+
+import (
+	"time"
+
+	"simulador-hard/domain"
+)
+
+// Reading convierte la trama en la lectura extendida del dominio
+func (f Frame) Reading(id, sensorID string, systemID int, timestamp time.Time) domain.PMS5003Reading {
+	return domain.PMS5003Reading{
+		ID:        id,
+		SensorID:  sensorID,
+		SystemID:  systemID,
+		PM1CF1:    int(f.PM1CF1),
+		PM25CF1:   int(f.PM25CF1),
+		PM10CF1:   int(f.PM10CF1),
+		PM1Atm:    int(f.PM1Atm),
+		PM25Atm:   int(f.PM25Atm),
+		PM10Atm:   int(f.PM10Atm),
+		Count03:   int(f.Counts[0]),
+		Count05:   int(f.Counts[1]),
+		Count10:   int(f.Counts[2]),
+		Count25:   int(f.Counts[3]),
+		Count50:   int(f.Counts[4]),
+		Count100:  int(f.Counts[5]),
+		Version:   int(f.Version),
+		ErrorCode: int(f.ErrorCode),
+		Timestamp: timestamp,
+	}
+}
@@ -0,0 +1,125 @@
+package hardware
+
+import (
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"simulador-hard/adapters/hardware/pms5003"
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+	"simulador-hard/ports"
+)
+
+// PMS5003Config activa las salidas byte a byte del sensor de partículas.
+// RawFrames publica la trama de 32 bytes en vigiltech/sensors/mesaN/pms5003/raw,
+// Extended publica domain.PMS5003Reading en vigiltech/sensors/mesaN/pms5003 y
+// CorruptRate es la probabilidad de dañar la trama enviada (MQTT crudo y pty).
+type PMS5003Config struct {
+	RawFrames   bool
+	Extended    bool
+	CorruptRate float64
+}
+
+// PMS5003Sensor publica domain.ParticleReading en vigiltech/sensors/mesaN/particles
+// y, según PMS5003Config, la trama binaria equivalente
+type PMS5003Sensor struct {
+	mu     sync.RWMutex
+	cfg    PMS5003Config
+	port   ports.DataPublisher
+	logger *slog.Logger
+}
+
+// NewPMS5003Sensor crea el sensor sin salidas adicionales
+func NewPMS5003Sensor() *PMS5003Sensor {
+	return &PMS5003Sensor{logger: logging.For("esp32")}
+}
+
+func (p *PMS5003Sensor) Kind() string          { return "pms5003" }
+func (p *PMS5003Sensor) Topic() string         { return "particles" }
+func (p *PMS5003Sensor) Period() time.Duration { return 2200 * time.Millisecond }
+
+// SetConfig configura las salidas adicionales
+func (p *PMS5003Sensor) SetConfig(cfg PMS5003Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+}
+
+// SetPort conecta el UART binario (por ejemplo un serial.FramePort)
+func (p *PMS5003Sensor) SetPort(port ports.DataPublisher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.port = port
+}
+
+func (p *PMS5003Sensor) Sample(host SensorHost, now time.Time) (interface{}, error) {
+	pm1, pm25, pm10 := host.Particles(now, host.Exposure(now))
+	return domain.ParticleReading{
+		ID:        uuid.New().String(),
+		SensorID:  host.SensorID("PM"),
+		SystemID:  host.MesaID(),
+		PM10:      pm1,
+		PM25:      pm25,
+		PM100:     pm10,
+		Timestamp: now,
+	}, nil
+}
+
+// Outputs genera la trama equivalente a la lectura, la escribe en el UART y
+// retorna las salidas MQTT activas
+func (p *PMS5003Sensor) Outputs(payload interface{}) []Output {
+	reading, ok := payload.(domain.ParticleReading)
+	if !ok {
+		return nil
+	}
+
+	p.mu.RLock()
+	cfg, port := p.cfg, p.port
+	p.mu.RUnlock()
+
+	portOpen := port != nil && port.IsConnected()
+	if !cfg.RawFrames && !cfg.Extended && !portOpen {
+		return nil
+	}
+
+	frame := pms5003.FromMass(reading.PM10, reading.PM25, reading.PM100)
+
+	var outputs []Output
+	if cfg.Extended {
+		extended := frame.Reading(uuid.New().String(), reading.SensorID, reading.SystemID, reading.Timestamp)
+		outputs = append(outputs, Output{Topic: "pms5003", Payload: extended})
+	}
+
+	data := frame.Encode()
+	if cfg.CorruptRate > 0 && rand.Float64() < cfg.CorruptRate {
+		var kind string
+		data, kind = pms5003.RandomCorrupt(data)
+		p.logger.Debug("trama PMS5003 corrupta", "mesa", reading.SystemID, "kind", kind)
+	}
+
+	if portOpen {
+		port.Publish("pms5003", data)
+	}
+	if cfg.RawFrames {
+		outputs = append(outputs, Output{Topic: "pms5003/raw", Payload: data})
+	}
+	return outputs
+}
+
+// SetPMS5003Config configura las salidas adicionales del PMS5003, si el ESP32 lo tiene
+func (s *ESP32HardwareSimulator) SetPMS5003Config(cfg PMS5003Config) {
+	if p, ok := s.sensor("pms5003").(*PMS5003Sensor); ok {
+		p.SetConfig(cfg)
+	}
+}
+
+// SetPMS5003Port conecta el UART binario del PMS5003, si el ESP32 lo tiene
+func (s *ESP32HardwareSimulator) SetPMS5003Port(port ports.DataPublisher) {
+	if p, ok := s.sensor("pms5003").(*PMS5003Sensor); ok {
+		p.SetPort(port)
+	}
+}
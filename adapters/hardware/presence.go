@@ -0,0 +1,55 @@
+package hardware
+
+import (
+	"math/rand"
+	"time"
+
+	"simulador-hard/adapters/hardware/hcsr501"
+)
+
+// Parámetros de las personas que pasan frente al PIR USB
+const (
+	presenceArrivalRate = 1.0 / 30 // llegadas por segundo
+	presenceMinStay     = 3 * time.Second
+	presenceMaxStay     = 20 * time.Second
+	presenceMinDistance = 1.0
+	presenceMaxDistance = 8.0
+	// Probabilidad de que una persona presente se mueva en cada paso
+	presenceMoving = 0.8
+	// Distancia de una ráfaga forzada por escenario
+	forcedDistance = 2.0
+)
+
+// PresenceSource entrega lo que ve el PIR USB en cada instante, por ejemplo
+// la simulación de ocupación; sin fuente se usa presenceModel
+type PresenceSource interface {
+	Presence(t time.Time) hcsr501.Presence
+}
+
+// presenceModel genera la presencia aleatoria frente al PIR: llegadas de
+// Poisson con una estadía y una distancia al azar
+type presenceModel struct {
+	last     time.Time
+	until    time.Time
+	distance float64
+}
+
+// next retorna la presencia en t; quiet impide nuevas llegadas
+func (m *presenceModel) next(t time.Time, quiet bool) hcsr501.Presence {
+	dt := time.Duration(0)
+	if !m.last.IsZero() {
+		dt = t.Sub(m.last)
+	}
+	m.last = t
+
+	if t.Before(m.until) {
+		return hcsr501.Presence{Moving: rand.Float64() < presenceMoving, Distance: m.distance}
+	}
+	if !quiet && rand.Float64() < presenceArrivalRate*dt.Seconds() {
+		stay := presenceMinStay + time.Duration(rand.Int63n(int64(presenceMaxStay-presenceMinStay)))
+		m.until = t.Add(stay)
+		m.distance = presenceMinDistance + rand.Float64()*(presenceMaxDistance-presenceMinDistance)
+		return hcsr501.Presence{Moving: true, Distance: m.distance}
+	}
+	return hcsr501.Presence{}
+}
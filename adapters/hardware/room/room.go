@@ -0,0 +1,281 @@
+// Package room simula la sala como una grilla 2D donde los gases y partículas
+// liberados en un punto se difunden y se ventilan. Cada mesa muestrea la
+// concentración en su posición, así una fuga cerca de la mesa 2 llega a las
+// mesas 1 y 3 con retardo y atenuación.
+//
+// Las concentraciones son el exceso sobre la línea base de cada sensor
+// (ppm para gases, µg/m³ para PM2.5) y se asumen homogéneas en altura.
+package room
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"simulador-hard/logging"
+)
+
+// Species es una sustancia transportada por el aire de la sala
+type Species int
+
+const (
+	LPG Species = iota
+	CO
+	Smoke
+	PM
+	numSpecies
+)
+
+var speciesNames = [numSpecies]string{"lpg", "co", "smoke", "pm"}
+
+func (s Species) String() string {
+	if s < 0 || s >= numSpecies {
+		return fmt.Sprintf("species(%d)", int(s))
+	}
+	return speciesNames[s]
+}
+
+// ParseSpecies interpreta lpg, co, smoke o pm
+func ParseSpecies(name string) (Species, error) {
+	for i, n := range speciesNames {
+		if n == name {
+			return Species(i), nil
+		}
+	}
+	return 0, fmt.Errorf("room: especie desconocida %q", name)
+}
+
+// Point es una posición en metros desde la esquina de la sala
+type Point struct {
+	X, Y float64
+}
+
+// Config define la geometría y la física de la sala
+type Config struct {
+	Width, Depth float64       // metros
+	CellSize     float64       // resolución de la grilla, metros
+	Diffusivity  float64       // difusión turbulenta efectiva, m²/s
+	AirChanges   float64       // renovaciones de aire por hora
+	Mesas        map[int]Point // posición de cada ESP32
+
+	// Fugas espontáneas cerca de una mesa al azar (eventos por segundo)
+	LeakRate     float64
+	LeakStrength float64       // emisión, ppm·m²/s
+	LeakDuration time.Duration // duración de cada fuga
+}
+
+// DefaultConfig ubica las mesas en fila a lo largo de una sala de 12x6 m
+func DefaultConfig(mesas int) Config {
+	cfg := Config{
+		Width:        12,
+		Depth:        6,
+		CellSize:     0.5,
+		Diffusivity:  0.05,
+		AirChanges:   4,
+		Mesas:        make(map[int]Point, mesas),
+		LeakRate:     0.01,
+		LeakStrength: 120,
+		LeakDuration: 10 * time.Second,
+	}
+	for i := 1; i <= mesas; i++ {
+		cfg.Mesas[i] = Point{X: (float64(i) - 0.5) * cfg.Width / float64(mesas), Y: cfg.Depth / 2}
+	}
+	return cfg
+}
+
+// Release es una emisión continua en un punto durante Duration
+type Release struct {
+	Species  Species
+	At       Point
+	Rate     float64 // ppm·m²/s (µg/m³·m²/s para PM)
+	Duration time.Duration
+}
+
+type activeRelease struct {
+	Release
+	cell  int
+	until time.Time
+}
+
+// Room es el estado de la sala; se avanza bajo demanda hasta el instante pedido
+type Room struct {
+	cfg      Config
+	mu       sync.Mutex
+	nx, ny   int
+	conc     [numSpecies][]float64
+	scratch  []float64
+	releases []activeRelease
+	now      time.Time
+	maxStep  time.Duration
+	logger   *slog.Logger
+}
+
+// New crea la sala vacía en el instante start
+func New(cfg Config, start time.Time) *Room {
+	nx := int(math.Ceil(cfg.Width / cfg.CellSize))
+	ny := int(math.Ceil(cfg.Depth / cfg.CellSize))
+	r := &Room{
+		cfg:     cfg,
+		nx:      nx,
+		ny:      ny,
+		scratch: make([]float64, nx*ny),
+		now:     start,
+		logger:  logging.For("room"),
+	}
+	for s := range r.conc {
+		r.conc[s] = make([]float64, nx*ny)
+	}
+
+	// Estabilidad del esquema explícito: dt <= dx² / (4D)
+	step := cfg.CellSize * cfg.CellSize / (4 * cfg.Diffusivity) * 0.9
+	r.maxStep = time.Duration(step * float64(time.Second))
+	if r.maxStep <= 0 || r.maxStep > time.Second {
+		r.maxStep = time.Second
+	}
+	return r
+}
+
+// Emit agrega una emisión que empieza en el instante actual de la sala
+func (r *Room) Emit(rel Release) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.emitLocked(rel)
+}
+
+func (r *Room) emitLocked(rel Release) {
+	r.releases = append(r.releases, activeRelease{
+		Release: rel,
+		cell:    r.cellAt(rel.At),
+		until:   r.now.Add(rel.Duration),
+	})
+	r.logger.Info("emisión en la sala", "species", rel.Species, "x", rel.At.X, "y", rel.At.Y, "rate", rel.Rate, "duration", rel.Duration)
+}
+
+// Mesa retorna la posición de una mesa
+func (r *Room) Mesa(id int) (Point, bool) {
+	p, ok := r.cfg.Mesas[id]
+	return p, ok
+}
+
+// Sample avanza la simulación hasta t y retorna la concentración en la mesa
+func (r *Room) Sample(mesaID int, species Species, t time.Time) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.advance(t)
+	p, ok := r.cfg.Mesas[mesaID]
+	if !ok {
+		return 0
+	}
+	return r.conc[species][r.cellAt(p)]
+}
+
+// SampleAll retorna las concentraciones de todas las especies en la mesa
+func (r *Room) SampleAll(mesaID int, t time.Time) [numSpecies]float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.advance(t)
+	var out [numSpecies]float64
+	p, ok := r.cfg.Mesas[mesaID]
+	if !ok {
+		return out
+	}
+	cell := r.cellAt(p)
+	for s := range out {
+		out[s] = r.conc[s][cell]
+	}
+	return out
+}
+
+// advance integra en pasos estables hasta t
+func (r *Room) advance(t time.Time) {
+	for r.now.Before(t) {
+		dt := t.Sub(r.now)
+		if dt > r.maxStep {
+			dt = r.maxStep
+		}
+		r.step(dt)
+		r.now = r.now.Add(dt)
+	}
+}
+
+func (r *Room) step(dt time.Duration) {
+	seconds := dt.Seconds()
+	r.spontaneousLeaks(seconds)
+
+	cellArea := r.cfg.CellSize * r.cfg.CellSize
+	kept := r.releases[:0]
+	for _, rel := range r.releases {
+		if r.now.Before(rel.until) {
+			r.conc[rel.Species][rel.cell] += rel.Rate * seconds / cellArea
+			kept = append(kept, rel)
+		}
+	}
+	r.releases = kept
+
+	alpha := r.cfg.Diffusivity * seconds / cellArea
+	ventilation := math.Exp(-r.cfg.AirChanges / 3600 * seconds)
+	for s := range r.conc {
+		r.diffuse(r.conc[s], alpha, ventilation)
+	}
+}
+
+// diffuse aplica un paso de difusión con paredes sin flujo y la ventilación
+func (r *Room) diffuse(c []float64, alpha, ventilation float64) {
+	next := r.scratch
+	for y := 0; y < r.ny; y++ {
+		for x := 0; x < r.nx; x++ {
+			i := y*r.nx + x
+			center := c[i]
+			var laplacian float64
+			if x > 0 {
+				laplacian += c[i-1] - center
+			}
+			if x < r.nx-1 {
+				laplacian += c[i+1] - center
+			}
+			if y > 0 {
+				laplacian += c[i-r.nx] - center
+			}
+			if y < r.ny-1 {
+				laplacian += c[i+r.nx] - center
+			}
+			next[i] = (center + alpha*laplacian) * ventilation
+		}
+	}
+	copy(c, next)
+}
+
+// spontaneousLeaks genera fugas al azar cerca de una mesa
+func (r *Room) spontaneousLeaks(seconds float64) {
+	if r.cfg.LeakRate <= 0 || len(r.cfg.Mesas) == 0 {
+		return
+	}
+	if rand.Float64() >= 1-math.Exp(-r.cfg.LeakRate*seconds) {
+		return
+	}
+
+	ids := make([]int, 0, len(r.cfg.Mesas))
+	for id := range r.cfg.Mesas {
+		ids = append(ids, id)
+	}
+	near := r.cfg.Mesas[ids[rand.Intn(len(ids))]]
+	r.emitLocked(Release{
+		Species:  Species(rand.Intn(int(numSpecies))),
+		At:       Point{X: near.X + rand.NormFloat64()*0.5, Y: near.Y + rand.NormFloat64()*0.5},
+		Rate:     r.cfg.LeakStrength * (0.5 + rand.Float64()),
+		Duration: r.cfg.LeakDuration,
+	})
+}
+
+func (r *Room) cellAt(p Point) int {
+	x := int(p.X / r.cfg.CellSize)
+	y := int(p.Y / r.cfg.CellSize)
+	x = min(max(x, 0), r.nx-1)
+	y = min(max(y, 0), r.ny-1)
+	return y*r.nx + x
+}
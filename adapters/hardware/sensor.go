@@ -0,0 +1,110 @@
+package hardware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sensor es un sensor montado en un ESP32. El simulador lo muestrea cada
+// Period y publica el payload en vigiltech/sensors/mesaN/<Topic>.
+type Sensor interface {
+	Kind() string
+	Topic() string
+	Period() time.Duration
+	// Sample retorna la lectura en now, un tipo de domain; un error descarta
+	// la muestra (por ejemplo un checksum fallido del DHT22)
+	Sample(host SensorHost, now time.Time) (interface{}, error)
+}
+
+// PoweredSensor se reinicia en cada encendido del ESP32 (precalentamientos)
+type PoweredSensor interface {
+	PowerOn(t time.Time)
+}
+
+// OutputSensor publica salidas propias derivadas de cada lectura (tramas,
+// versiones extendidas) en vigiltech/sensors/mesaN/<Output.Topic>
+type OutputSensor interface {
+	Outputs(payload interface{}) []Output
+}
+
+// Output es un payload adicional de un sensor
+type Output struct {
+	Topic   string
+	Payload interface{}
+}
+
+// SensorHost es lo que el ESP32 comparte con sus sensores: los canales de
+// señal de la mesa, de modo que sensores distintos ven el mismo ambiente
+type SensorHost interface {
+	MesaID() int
+	SensorID(suffix string) string
+	Exposure(t time.Time) Exposure
+	Gas(t time.Time, exp Exposure) (lpg, co, smoke float64)
+	Ambient(t time.Time) (temperature, humidity float64)
+	Particles(t time.Time, exp Exposure) (pm1, pm25, pm10 float64)
+}
+
+// SensorFactory crea una instancia nueva de un tipo de sensor para un ESP32
+type SensorFactory func() Sensor
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]SensorFactory{
+		"mq135":   func() Sensor { return NewMQ135Sensor() },
+		"pms5003": func() Sensor { return NewPMS5003Sensor() },
+		"dht22":   func() Sensor { return NewDHT22Sensor() },
+		"flame":   func() Sensor { return NewFlameSensor() },
+		"sound":   func() Sensor { return NewSoundSensor() },
+		"mhz19":   func() Sensor { return NewMHZ19Sensor() },
+	}
+)
+
+// DefaultSensors son los sensores del ESP32 original
+const DefaultSensors = "mq135,pms5003"
+
+// RegisterSensor agrega o reemplaza un tipo de sensor
+func RegisterSensor(kind string, factory SensorFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[kind] = factory
+}
+
+// SensorKinds lista los tipos registrados
+func SensorKinds() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	kinds := make([]string, 0, len(registry))
+	for kind := range registry {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// NewSensors crea los sensores de una lista de tipos ("mq135,pms5003,dht22")
+func NewSensors(kinds string) ([]Sensor, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var sensors []Sensor
+	seen := make(map[string]bool)
+	for _, kind := range strings.Split(kinds, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		factory, ok := registry[kind]
+		if !ok {
+			return nil, fmt.Errorf("hardware: sensor desconocido %q", kind)
+		}
+		if seen[kind] {
+			return nil, fmt.Errorf("hardware: sensor %q repetido", kind)
+		}
+		seen[kind] = true
+		sensors = append(sensors, factory())
+	}
+	return sensors, nil
+}
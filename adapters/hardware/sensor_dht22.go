@@ -0,0 +1,64 @@
+package hardware
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+
+	"simulador-hard/domain"
+)
+
+// Errores de lectura del bus de un hilo del DHT22
+var (
+	ErrDHT22Checksum = errors.New("dht22: checksum inválido")
+	ErrDHT22Timeout  = errors.New("dht22: sin respuesta del sensor")
+)
+
+// DHT22Sensor publica domain.ClimateReading en vigiltech/sensors/mesaN/climate.
+// Mide la misma temperatura y humedad que usa la compensación del MQ-135, con
+// el error de calibración de cada unidad (±0.5 °C, ±2 %RH) y resolución 0.1.
+type DHT22Sensor struct {
+	tempOffset, humOffset float64
+	// ErrorRate es la probabilidad de una lectura fallida
+	ErrorRate float64
+}
+
+// NewDHT22Sensor crea una unidad con su propio error de calibración
+func NewDHT22Sensor() *DHT22Sensor {
+	return &DHT22Sensor{
+		tempOffset: (rand.Float64()*2 - 1) * 0.5,
+		humOffset:  (rand.Float64()*2 - 1) * 2,
+		ErrorRate:  0.01,
+	}
+}
+
+func (d *DHT22Sensor) Kind() string  { return "dht22" }
+func (d *DHT22Sensor) Topic() string { return "climate" }
+
+// Period es el intervalo mínimo entre lecturas que admite el DHT22
+func (d *DHT22Sensor) Period() time.Duration { return 2 * time.Second }
+
+func (d *DHT22Sensor) Sample(host SensorHost, now time.Time) (interface{}, error) {
+	temperature, humidity := host.Ambient(now)
+	if rand.Float64() < d.ErrorRate {
+		if rand.Intn(2) == 0 {
+			return nil, ErrDHT22Checksum
+		}
+		return nil, ErrDHT22Timeout
+	}
+
+	temperature += d.tempOffset + rand.NormFloat64()*0.1
+	humidity = math.Max(0, math.Min(100, humidity+d.humOffset+rand.NormFloat64()*0.3))
+
+	return domain.ClimateReading{
+		ID:          uuid.New().String(),
+		SensorID:    host.SensorID("DHT22"),
+		SystemID:    host.MesaID(),
+		Temperature: math.Round(temperature*10) / 10,
+		Humidity:    math.Round(humidity*10) / 10,
+		Timestamp:   now,
+	}, nil
+}
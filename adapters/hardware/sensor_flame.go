@@ -0,0 +1,60 @@
+package hardware
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"simulador-hard/adapters/hardware/signal"
+	"simulador-hard/domain"
+)
+
+// Parámetros del módulo de llama IR (fototransistor de 760-1100 nm)
+const (
+	// DefaultFlameThreshold es el punto del comparador ajustado con el potenciómetro
+	DefaultFlameThreshold = 2000
+	// flameCountsPerPPM aproxima la radiación IR de una combustión en la mesa a
+	// partir del humo que genera
+	flameCountsPerPPM = 2.0
+	flameADCMax       = 4095
+)
+
+// FlameSensor publica domain.FlameReading en vigiltech/sensors/mesaN/flame.
+// El fondo es la luz ambiente con reflejos ocasionales; una combustión en la
+// mesa (humo en la exposición) sube el nivel IR hasta activar la salida digital.
+type FlameSensor struct {
+	Threshold  int
+	background signal.Model
+}
+
+// NewFlameSensor crea el sensor con el umbral por defecto
+func NewFlameSensor() *FlameSensor {
+	return &FlameSensor{
+		Threshold: DefaultFlameThreshold,
+		background: signal.Clamp{Min: 0, Max: flameADCMax, Spec: signal.Sum{
+			signal.OrnsteinUhlenbeck{Mean: 150, StdDev: 30, Tau: 10 * time.Second},
+			// Reflejos o un encendedor cerca: rara vez llegan al umbral
+			signal.Decay{Rate: 0.002, MinAmplitude: 300, MaxAmplitude: 900, Tau: 5 * time.Second},
+		}}.New(),
+	}
+}
+
+func (f *FlameSensor) Kind() string          { return "flame" }
+func (f *FlameSensor) Topic() string         { return "flame" }
+func (f *FlameSensor) Period() time.Duration { return time.Second }
+
+func (f *FlameSensor) Sample(host SensorHost, now time.Time) (interface{}, error) {
+	exp := host.Exposure(now)
+	level := f.background.Next(now) + flameCountsPerPPM*exp.Smoke
+	ir := int(math.Round(math.Max(0, math.Min(flameADCMax, level))))
+
+	return domain.FlameReading{
+		ID:            uuid.New().String(),
+		SensorID:      host.SensorID("FLAME"),
+		SystemID:      host.MesaID(),
+		FlameDetected: ir >= f.Threshold,
+		IRLevel:       ir,
+		Timestamp:     now,
+	}, nil
+}
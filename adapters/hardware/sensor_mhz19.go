@@ -0,0 +1,74 @@
+package hardware
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"simulador-hard/adapters/hardware/signal"
+	"simulador-hard/domain"
+)
+
+// Parámetros del MH-Z19B
+const (
+	mhz19WarmUp     = 3 * time.Minute
+	mhz19WarmUpPPM  = 400
+	mhz19MaxPPM     = 5000
+	mhz19OutdoorPPM = 400
+	// co2PerCO aproxima el CO2 de una combustión a partir del CO que produce
+	co2PerCO = 5.0
+)
+
+// MHZ19Sensor publica domain.CO2Reading en vigiltech/sensors/mesaN/co2. El CO2
+// sube con la ocupación durante el día y con la combustión (vía el CO de la
+// exposición); durante el precalentamiento reporta un valor fijo.
+type MHZ19Sensor struct {
+	mu      sync.Mutex
+	level   signal.Model
+	powered time.Time
+}
+
+// NewMHZ19Sensor crea el sensor con el perfil de un aula ventilada
+func NewMHZ19Sensor() *MHZ19Sensor {
+	return &MHZ19Sensor{
+		level: signal.Sum{
+			signal.OrnsteinUhlenbeck{Mean: 600, StdDev: 50, Tau: 10 * time.Minute},
+			signal.Diurnal{Amplitude: 150, PeakHour: 16},
+		}.New(),
+	}
+}
+
+func (m *MHZ19Sensor) Kind() string          { return "mhz19" }
+func (m *MHZ19Sensor) Topic() string         { return "co2" }
+func (m *MHZ19Sensor) Period() time.Duration { return 5 * time.Second }
+
+// PowerOn reinicia el precalentamiento de 3 minutos
+func (m *MHZ19Sensor) PowerOn(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.powered = t
+}
+
+func (m *MHZ19Sensor) Sample(host SensorHost, now time.Time) (interface{}, error) {
+	exp := host.Exposure(now)
+
+	m.mu.Lock()
+	co2 := m.level.Next(now) + co2PerCO*exp.CO
+	warming := now.Sub(m.powered) < mhz19WarmUp
+	m.mu.Unlock()
+
+	if warming {
+		co2 = mhz19WarmUpPPM
+	}
+
+	return domain.CO2Reading{
+		ID:        uuid.New().String(),
+		SensorID:  host.SensorID("CO2"),
+		SystemID:  host.MesaID(),
+		CO2:       math.Round(math.Max(mhz19OutdoorPPM, math.Min(mhz19MaxPPM, co2))),
+		Warming:   warming,
+		Timestamp: now,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package hardware
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"simulador-hard/adapters/hardware/mq135"
+	"simulador-hard/domain"
+)
+
+// MQ135Sensor publica domain.GasReading en vigiltech/sensors/mesaN/gas a partir
+// del modelo eléctrico del MQ-135
+type MQ135Sensor struct {
+	mu     sync.Mutex
+	cfg    mq135.Config
+	sensor *mq135.Sensor
+}
+
+// NewMQ135Sensor crea el sensor con los parámetros por defecto
+func NewMQ135Sensor() *MQ135Sensor {
+	return &MQ135Sensor{cfg: mq135.DefaultConfig()}
+}
+
+func (m *MQ135Sensor) Kind() string          { return "mq135" }
+func (m *MQ135Sensor) Topic() string         { return "gas" }
+func (m *MQ135Sensor) Period() time.Duration { return 1800 * time.Millisecond }
+
+// SetConfig cambia los parámetros eléctricos; se aplica en el próximo encendido
+func (m *MQ135Sensor) SetConfig(cfg mq135.Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cfg = cfg
+}
+
+// PowerOn reinicia el precalentamiento del calefactor
+func (m *MQ135Sensor) PowerOn(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sensor = mq135.NewSensor(m.cfg, t)
+}
+
+func (m *MQ135Sensor) Sample(host SensorHost, now time.Time) (interface{}, error) {
+	lpg, co, smoke := host.Gas(now, host.Exposure(now))
+	temperature, humidity := host.Ambient(now)
+
+	m.mu.Lock()
+	if m.sensor == nil {
+		m.sensor = mq135.NewSensor(m.cfg, now)
+	}
+	sample := m.sensor.Measure(now, lpg, co, smoke, temperature, humidity)
+	m.mu.Unlock()
+
+	return domain.GasReading{
+		ID:        uuid.New().String(),
+		SensorID:  host.SensorID("GAS"),
+		SystemID:  host.MesaID(),
+		LPG:       sample.LPG.PPM,
+		CO:        sample.CO.PPM,
+		Smoke:     sample.Smoke.PPM,
+		Timestamp: now,
+		Raw: &domain.MQ135Raw{
+			ADCLPG:      sample.LPG.ADC,
+			ADCCO:       sample.CO.ADC,
+			ADCSmoke:    sample.Smoke.ADC,
+			Temperature: temperature,
+			Humidity:    humidity,
+			Warming:     sample.Warming,
+		},
+	}, nil
+}
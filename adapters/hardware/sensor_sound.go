@@ -0,0 +1,43 @@
+package hardware
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"simulador-hard/adapters/hardware/signal"
+	"simulador-hard/domain"
+)
+
+// SoundSensor publica domain.SoundReading en vigiltech/sensors/mesaN/sound: el
+// ruido de fondo del laboratorio, más alto en horario de clases, con golpes y
+// voces que decaen en pocos segundos
+type SoundSensor struct {
+	level signal.Model
+}
+
+// NewSoundSensor crea el sensor con el perfil de un laboratorio
+func NewSoundSensor() *SoundSensor {
+	return &SoundSensor{
+		level: signal.Clamp{Min: 30, Max: 120, Spec: signal.Sum{
+			signal.OrnsteinUhlenbeck{Mean: 42, StdDev: 3, Tau: 20 * time.Second},
+			signal.Diurnal{Amplitude: 8, PeakHour: 14},
+			signal.Decay{Rate: 0.03, MinAmplitude: 10, MaxAmplitude: 30, Tau: 2 * time.Second},
+		}}.New(),
+	}
+}
+
+func (s *SoundSensor) Kind() string          { return "sound" }
+func (s *SoundSensor) Topic() string         { return "sound" }
+func (s *SoundSensor) Period() time.Duration { return time.Second }
+
+func (s *SoundSensor) Sample(host SensorHost, now time.Time) (interface{}, error) {
+	return domain.SoundReading{
+		ID:        uuid.New().String(),
+		SensorID:  host.SensorID("SOUND"),
+		SystemID:  host.MesaID(),
+		LevelDB:   math.Round(s.level.Next(now)*10) / 10,
+		Timestamp: now,
+	}, nil
+}
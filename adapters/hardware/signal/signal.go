@@ -0,0 +1,219 @@
+// Package signal genera series temporales correlacionadas para los canales de
+// los sensores simulados. Cada Spec describe un modelo con sus parámetros y New
+// crea una instancia con estado propio, de modo que cada mesa evoluciona por separado.
+package signal
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Model produce el valor del canal en el instante t. Las llamadas deben hacerse
+// con t creciente; la primera llamada retorna el valor inicial del modelo.
+type Model interface {
+	Next(t time.Time) float64
+}
+
+// Spec crea modelos con estado a partir de sus parámetros
+type Spec interface {
+	New() Model
+}
+
+// clock calcula el paso entre llamadas sucesivas
+type clock struct {
+	last time.Time
+}
+
+func (c *clock) step(t time.Time) time.Duration {
+	if c.last.IsZero() {
+		c.last = t
+		return 0
+	}
+	dt := t.Sub(c.last)
+	c.last = t
+	if dt < 0 {
+		return 0
+	}
+	return dt
+}
+
+// Constant retorna siempre el mismo valor
+type Constant float64
+
+func (c Constant) New() Model { return c }
+
+func (c Constant) Next(time.Time) float64 { return float64(c) }
+
+// Uniform muestrea cada valor de forma independiente (comportamiento original)
+type Uniform struct {
+	Min, Max float64
+}
+
+func (u Uniform) New() Model { return u }
+
+func (u Uniform) Next(time.Time) float64 {
+	return u.Min + rand.Float64()*(u.Max-u.Min)
+}
+
+// RandomWalk es un movimiento browniano que rebota en [Min, Max] si Max > Min.
+// Sigma es la desviación acumulada en un segundo.
+type RandomWalk struct {
+	Start    float64
+	Sigma    float64
+	Min, Max float64
+}
+
+func (s RandomWalk) New() Model {
+	return &randomWalk{spec: s, value: s.Start}
+}
+
+type randomWalk struct {
+	spec  RandomWalk
+	value float64
+	clock clock
+}
+
+func (m *randomWalk) Next(t time.Time) float64 {
+	dt := m.clock.step(t).Seconds()
+	m.value += m.spec.Sigma * math.Sqrt(dt) * rand.NormFloat64()
+	if m.spec.Max > m.spec.Min {
+		m.value = reflect(m.value, m.spec.Min, m.spec.Max)
+	}
+	return m.value
+}
+
+// OrnsteinUhlenbeck vuelve a la media con constante de tiempo Tau.
+// StdDev es la desviación estacionaria alrededor de Mean.
+type OrnsteinUhlenbeck struct {
+	Mean   float64
+	StdDev float64
+	Tau    time.Duration
+}
+
+func (s OrnsteinUhlenbeck) New() Model {
+	return &ornsteinUhlenbeck{spec: s, value: s.Mean}
+}
+
+type ornsteinUhlenbeck struct {
+	spec  OrnsteinUhlenbeck
+	value float64
+	clock clock
+}
+
+func (m *ornsteinUhlenbeck) Next(t time.Time) float64 {
+	dt := m.clock.step(t)
+	if dt == 0 || m.spec.Tau <= 0 {
+		return m.value
+	}
+	// Discretización exacta: la varianza estacionaria no depende del paso
+	decay := math.Exp(-dt.Seconds() / m.spec.Tau.Seconds())
+	m.value = m.spec.Mean + (m.value-m.spec.Mean)*decay +
+		m.spec.StdDev*math.Sqrt(1-decay*decay)*rand.NormFloat64()
+	return m.value
+}
+
+// Diurnal es una línea base sinusoidal con máximo a la hora PeakHour.
+// Period por defecto es 24h; valores menores sirven para acelerar pruebas.
+type Diurnal struct {
+	Base      float64
+	Amplitude float64
+	PeakHour  float64
+	Period    time.Duration
+}
+
+func (s Diurnal) New() Model { return s }
+
+func (s Diurnal) Next(t time.Time) float64 {
+	period := s.Period
+	if period <= 0 {
+		period = 24 * time.Hour
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	elapsed := t.Sub(midnight) - time.Duration(s.PeakHour*float64(time.Hour))
+	phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+	return s.Base + s.Amplitude*math.Cos(phase)
+}
+
+// Decay suma picos que decaen exponencialmente con constante Tau. Los picos
+// llegan como proceso de Poisson con Rate eventos por segundo y amplitud
+// uniforme en [MinAmplitude, MaxAmplitude].
+type Decay struct {
+	Rate         float64
+	MinAmplitude float64
+	MaxAmplitude float64
+	Tau          time.Duration
+}
+
+func (s Decay) New() Model {
+	return &decay{spec: s}
+}
+
+type decay struct {
+	spec  Decay
+	level float64
+	clock clock
+}
+
+func (m *decay) Next(t time.Time) float64 {
+	dt := m.clock.step(t)
+	if dt > 0 && m.spec.Tau > 0 {
+		m.level *= math.Exp(-dt.Seconds() / m.spec.Tau.Seconds())
+	}
+	if m.spec.Rate > 0 && rand.Float64() < 1-math.Exp(-m.spec.Rate*dt.Seconds()) {
+		m.level += m.spec.MinAmplitude + rand.Float64()*(m.spec.MaxAmplitude-m.spec.MinAmplitude)
+	}
+	return m.level
+}
+
+// Sum suma varios modelos, por ejemplo línea base + ruido + picos
+type Sum []Spec
+
+func (s Sum) New() Model {
+	models := make(sum, len(s))
+	for i, spec := range s {
+		models[i] = spec.New()
+	}
+	return models
+}
+
+type sum []Model
+
+func (m sum) Next(t time.Time) float64 {
+	var total float64
+	for _, model := range m {
+		total += model.Next(t)
+	}
+	return total
+}
+
+// Clamp limita la salida de otro modelo a [Min, Max]
+type Clamp struct {
+	Spec     Spec
+	Min, Max float64
+}
+
+func (s Clamp) New() Model {
+	return &clamp{spec: s, inner: s.Spec.New()}
+}
+
+type clamp struct {
+	spec  Clamp
+	inner Model
+}
+
+func (m *clamp) Next(t time.Time) float64 {
+	return math.Min(math.Max(m.inner.Next(t), m.spec.Min), m.spec.Max)
+}
+
+func reflect(v, min, max float64) float64 {
+	for v < min || v > max {
+		if v < min {
+			v = 2*min - v
+		}
+		if v > max {
+			v = 2*max - v
+		}
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package hardware
+
+import (
+	"math"
+	"time"
+
+	"simulador-hard/adapters/hardware/signal"
+)
+
+// SignalConfig define el modelo de cada canal del ESP32.
+// Las partículas se construyen en cascada para respetar PM1.0 <= PM2.5 <= PM10:
+// PM2.5 = PM1.0 + PM25Delta y PM10 = PM2.5 + PM10Delta, todo multiplicado por
+// (1 + Contamination) para los episodios de contaminación.
+type SignalConfig struct {
+	LPG   signal.Spec
+	CO    signal.Spec
+	Smoke signal.Spec
+
+	// Ambiente para la compensación del MQ-135 (°C y %RH)
+	Temperature signal.Spec
+	Humidity    signal.Spec
+
+	PM1           signal.Spec
+	PM25Delta     signal.Spec
+	PM10Delta     signal.Spec
+	Contamination signal.Spec
+}
+
+// DefaultSignalConfig aproxima los rangos de la simulación original con series
+// correlacionadas: media con reversión, ciclo diario y picos que decaen
+func DefaultSignalConfig() SignalConfig {
+	return signalConfig(true)
+}
+
+// BaselineSignalConfig es DefaultSignalConfig sin picos propios, para cuando
+// los eventos llegan desde un Environment compartido (por ejemplo la sala)
+func BaselineSignalConfig() SignalConfig {
+	return signalConfig(false)
+}
+
+// CorrelatedSignalConfig es DefaultSignalConfig con el humo sin picos propios:
+// los episodios de combustión llegan desde CorrelatedEnvironment junto con su
+// CO y sus partículas
+func CorrelatedSignalConfig() SignalConfig {
+	cfg := DefaultSignalConfig()
+	cfg.Smoke = BaselineSignalConfig().Smoke
+	return cfg
+}
+
+func signalConfig(spikes bool) SignalConfig {
+	gas := func(mean, peakHour float64) signal.Spec {
+		channel := signal.Sum{
+			signal.OrnsteinUhlenbeck{Mean: mean, StdDev: 35, Tau: 30 * time.Second},
+			signal.Diurnal{Amplitude: 0.15 * mean, PeakHour: peakHour},
+		}
+		if spikes {
+			// Un pico cada ~50s por gas; rara vez supera el umbral de alerta
+			channel = append(channel, signal.Decay{Rate: 0.02, MinAmplitude: 100, MaxAmplitude: 350, Tau: 20 * time.Second})
+		}
+		return signal.Clamp{Min: 0, Max: math.Inf(1), Spec: channel}
+	}
+	positive := func(spec signal.Spec) signal.Spec {
+		return signal.Clamp{Spec: spec, Min: 0, Max: math.Inf(1)}
+	}
+
+	cfg := SignalConfig{
+		LPG:   gas(275, 13),
+		CO:    gas(200, 19),
+		Smoke: gas(235, 20),
+
+		Temperature: signal.Sum{signal.Diurnal{Base: 22, Amplitude: 3, PeakHour: 15}, signal.OrnsteinUhlenbeck{StdDev: 0.3, Tau: 5 * time.Minute}},
+		Humidity:    signal.Clamp{Min: 10, Max: 95, Spec: signal.Sum{signal.Diurnal{Base: 45, Amplitude: -8, PeakHour: 15}, signal.OrnsteinUhlenbeck{StdDev: 2, Tau: 5 * time.Minute}}},
+
+		PM1:       positive(signal.Sum{signal.OrnsteinUhlenbeck{Mean: 35, StdDev: 8, Tau: time.Minute}, signal.Diurnal{Amplitude: 6, PeakHour: 18}}),
+		PM25Delta: positive(signal.OrnsteinUhlenbeck{Mean: 20, StdDev: 5, Tau: time.Minute}),
+		PM10Delta: positive(signal.OrnsteinUhlenbeck{Mean: 32, StdDev: 6, Tau: time.Minute}),
+		// Factor extra de 0.5 a 1.5 como el contaminationFactor original
+		Contamination: signal.Decay{Rate: 0.02, MinAmplitude: 0.5, MaxAmplitude: 1.5, Tau: 45 * time.Second},
+	}
+	if !spikes {
+		cfg.Contamination = signal.Constant(0)
+	}
+	return cfg
+}
+
+// channelModels es la instancia con estado de un SignalConfig
+type channelModels struct {
+	lpg, co, smoke                           signal.Model
+	temperature, humidity                    signal.Model
+	pm1, pm25Delta, pm10Delta, contamination signal.Model
+}
+
+func newChannelModels(cfg SignalConfig) *channelModels {
+	return &channelModels{
+		lpg:           cfg.LPG.New(),
+		co:            cfg.CO.New(),
+		smoke:         cfg.Smoke.New(),
+		temperature:   cfg.Temperature.New(),
+		humidity:      cfg.Humidity.New(),
+		pm1:           cfg.PM1.New(),
+		pm25Delta:     cfg.PM25Delta.New(),
+		pm10Delta:     cfg.PM10Delta.New(),
+		contamination: cfg.Contamination.New(),
+	}
+}
+
+func (m *channelModels) gas(t time.Time, exp Exposure) (lpg, co, smoke float64) {
+	return m.lpg.Next(t) + exp.LPG, m.co.Next(t) + exp.CO, m.smoke.Next(t) + exp.Smoke
+}
+
+func (m *channelModels) ambient(t time.Time) (temperature, humidity float64) {
+	return m.temperature.Next(t), m.humidity.Next(t)
+}
+
+func (m *channelModels) particles(t time.Time, exp Exposure) (pm1, pm25, pm10 float64) {
+	factor := 1 + m.contamination.Next(t)
+	pm1 = m.pm1.Next(t) + exp.PM1
+	pm25 = pm1 + m.pm25Delta.Next(t) + exp.PM25 - exp.PM1
+	pm10 = pm25 + m.pm10Delta.Next(t) + exp.PM100 - exp.PM25
+	return pm1 * factor, pm25 * factor, pm10 * factor
+}
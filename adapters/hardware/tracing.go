@@ -0,0 +1,51 @@
+package hardware
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"simulador-hard/ports"
+)
+
+// tracer delega en el TracerProvider global; sin configurar es un no-op
+var tracer = otel.Tracer("simulador-hard/adapters/hardware")
+
+// motionEvent viaja del PIR a la cámara junto con el contexto de su span
+type motionEvent struct {
+	id  string
+	ctx context.Context
+}
+
+// traceMetadata serializa el contexto de traza para incluirlo en el payload
+func traceMetadata(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// publishTraced publica dentro de un span productor hijo de ctx
+func publishTraced(ctx context.Context, publisher ports.DataPublisher, topic string, payload interface{}) error {
+	_, span := tracer.Start(ctx, "publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("messaging.destination.name", topic)),
+	)
+	defer span.End()
+
+	err := publisher.Publish(topic, payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
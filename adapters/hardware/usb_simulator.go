@@ -1,115 +1,253 @@
 package hardware
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"math"
 	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"simulador-hard/adapters/hardware/hcsr501"
+	"simulador-hard/adapters/hardware/webcam"
 	"simulador-hard/domain"
+	"simulador-hard/logging"
 	"simulador-hard/ports"
 )
 
 type USBHardwareSimulator struct {
 	publisher  ports.DataPublisher
 	stopChan   chan struct{}
-	motionChan chan string
+	running    bool
+	motionChan chan motionEvent
 	mu         sync.RWMutex
 	lastMotion       domain.MotionReading
 	lastCamera       domain.CameraReading
 	lastCameraStream domain.CameraStreamReading
+	forcedUntil      time.Time
+	quietUntil       time.Time
+	pirConfig        hcsr501.Config
+	presence         PresenceSource
+	camera           *webcam.Camera
+	logger           *slog.Logger
 }
 
 func NewUSBSimulator(publisher ports.DataPublisher) *USBHardwareSimulator {
 	return &USBHardwareSimulator{
 		publisher:  publisher,
-		stopChan:   make(chan struct{}),
-		motionChan: make(chan string, 10),
+		motionChan: make(chan motionEvent, 10),
+		pirConfig:  hcsr501.DefaultConfig(),
+		logger:     logging.For("usb"),
 	}
 }
 
 func (s *USBHardwareSimulator) Start() {
-	go s.simulatePIRSensor()        // Goroutine 1: PIR cada 2.5s
-	go s.simulateWebcamCapture()    // Goroutine 2: Captura solo con movimiento
-	go s.simulateCameraStream()     // Goroutine 3: Stream cada 1s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.stopChan = make(chan struct{})
+	s.running = true
+
+	go s.simulatePIRSensor(s.stopChan)     // Goroutine 1: PIR cada 2.5s y en cada flanco
+	go s.simulateWebcamCapture(s.stopChan) // Goroutine 2: Captura solo con movimiento
+	go s.simulateCameraStream(s.stopChan)  // Goroutine 3: Stream cada 1s
+}
+
+// SetPIRConfig ajusta los potenciómetros y el jumper del HC-SR501; se aplica
+// en el próximo Start
+func (s *USBHardwareSimulator) SetPIRConfig(cfg hcsr501.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pirConfig = cfg
+}
+
+// SetPresenceSource reemplaza las llegadas aleatorias por una fuente de presencia
+func (s *USBHardwareSimulator) SetPresenceSource(src PresenceSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.presence = src
 }
 
-// PIR: SIEMPRE publica (detectado o no)
-func (s *USBHardwareSimulator) simulatePIRSensor() {
-	ticker := time.NewTicker(2500 * time.Millisecond)
+// SetCamera genera las imágenes localmente en lugar de usar URLs de picsum
+func (s *USBHardwareSimulator) SetCamera(camera *webcam.Camera) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.camera = camera
+}
+
+// ForceMotion pone a una persona moviéndose cerca del PIR durante d (ráfagas
+// de escenario); la salida sigue las reglas del HC-SR501
+func (s *USBHardwareSimulator) ForceMotion(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forcedUntil = time.Now().Add(d)
+	s.logger.Info("movimiento forzado", "duration", d)
+}
+
+// SuppressMotion ignora la presencia (llegadas aleatorias u ocupación) durante
+// d, como un laboratorio vacío; ForceMotion sigue teniendo prioridad
+func (s *USBHardwareSimulator) SuppressMotion(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quietUntil = time.Now().Add(d)
+	s.logger.Info("movimiento suprimido", "duration", d)
+}
+
+// Resolución del modelo del PIR y período de las lecturas sin cambios
+const (
+	pirStep      = 100 * time.Millisecond
+	pirHeartbeat = 2500 * time.Millisecond
+)
+
+// PIR: SIEMPRE publica (detectado o no) cada 2.5s y además en cada flanco de
+// la salida del HC-SR501, para que el backend vea la duración real del evento
+func (s *USBHardwareSimulator) simulatePIRSensor(stop <-chan struct{}) {
+	ticker := time.NewTicker(pirStep)
 	defer ticker.Stop()
 
+	s.mu.Lock()
+	pir := hcsr501.New(s.pirConfig, time.Now())
+	s.mu.Unlock()
+
+	var random presenceModel
+	var lastPublish time.Time
+	wasHigh := false
+	// Distancia de la última persona que disparó el PIR: durante la retención
+	// ya no hay nadie, pero la intensidad corresponde a ese disparo
+	triggerDistance := pir.Config().Range()
+
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stop:
 			return
-		case <-ticker.C:
-			detected := rand.Float64() > 0.65
-
-			intensity := 0.0
-			if detected {
-				intensity = 40.0 + rand.Float64()*60.0
-			} else {
-				intensity = rand.Float64() * 20.0
+		case now := <-ticker.C:
+			s.mu.RLock()
+			forced := now.Before(s.forcedUntil)
+			quiet := now.Before(s.quietUntil)
+			source := s.presence
+			s.mu.RUnlock()
+
+			var p hcsr501.Presence
+			switch {
+			case source == nil:
+				p = random.next(now, quiet)
+			case !quiet:
+				p = source.Presence(now)
 			}
-
-			motionID := uuid.New().String()
-
-			reading := domain.MotionReading{
-				ID:             motionID,
-				SensorID:       "USB-PIR-RPi",
-				SystemID:       0,
-				MotionDetected: detected,
-				Intensity:      intensity,
-				Timestamp:      time.Now(),
+			if forced {
+				p = hcsr501.Presence{Moving: true, Distance: forcedDistance}
+			}
+			detected := pir.Update(now, p)
+			if detected && p.Moving && p.Distance <= pir.Config().Range() {
+				triggerDistance = p.Distance
 			}
 
-			s.mu.Lock()
-			s.lastMotion = reading
-			s.mu.Unlock()
-
-			// SIEMPRE publicar (detectado o no)
-			if s.publisher != nil && s.publisher.IsConnected() {
-				if err := s.publisher.Publish("vigiltech/sensors/usb/motion", reading); err != nil {
-					log.Printf("ERROR publishing motion: %v", err)
-				}
+			if detected == wasHigh && now.Sub(lastPublish) < pirHeartbeat {
+				continue
 			}
+			if detected != wasHigh {
+				s.logger.Debug("flanco del PIR", "high", detected)
+			}
+			wasHigh = detected
+			lastPublish = now
 
-			// Solo enviar por canal si hay movimiento
+			intensity := rand.Float64() * 20.0
 			if detected {
-				select {
-				case s.motionChan <- motionID:
-					log.Printf("🚨 MOVIMIENTO DETECTADO - Motion ID: %s - Intensidad: %.1f%%", motionID, intensity)
-				default:
-				}
-			} else {
-				log.Printf("⚪ Sin movimiento - Intensidad: %.1f%%", intensity)
+				// Más cerca, más cambio de calor en el piroeléctrico
+				intensity = 40.0 + 50.0*(1-triggerDistance/pir.Config().Range()) + rand.Float64()*10.0
 			}
+			s.publishMotion(detected, math.Min(100, intensity))
+		}
+	}
+}
+
+// publishMotion publica una lectura del PIR y, si hay movimiento, avisa a la cámara
+func (s *USBHardwareSimulator) publishMotion(detected bool, intensity float64) {
+	motionID := uuid.New().String()
+
+	ctx, span := tracer.Start(context.Background(), "pir.motion_detect", trace.WithAttributes(
+		attribute.String("motion.id", motionID),
+		attribute.Bool("motion.detected", detected),
+		attribute.Float64("motion.intensity", intensity),
+	))
+	defer span.End()
+
+	reading := domain.MotionReading{
+		ID:             motionID,
+		SensorID:       "USB-PIR-RPi",
+		SystemID:       0,
+		MotionDetected: detected,
+		Intensity:      intensity,
+		Timestamp:      time.Now(),
+		Metadata:       traceMetadata(ctx),
+	}
+
+	s.mu.Lock()
+	s.lastMotion = reading
+	s.mu.Unlock()
+
+	// SIEMPRE publicar (detectado o no)
+	if s.publisher != nil && s.publisher.IsConnected() {
+		if err := publishTraced(ctx, s.publisher, "vigiltech/sensors/usb/motion", reading); err != nil {
+			s.logger.Error("error publicando movimiento", "error", err)
 		}
 	}
+
+	// Solo enviar por canal si hay movimiento
+	if !detected {
+		s.logger.Debug("sin movimiento", "intensity", intensity)
+		return
+	}
+	select {
+	case s.motionChan <- motionEvent{id: motionID, ctx: ctx}:
+		s.logger.Info("movimiento detectado", "motion_id", motionID, "intensity", intensity)
+	default:
+		span.AddEvent("motion.dropped", trace.WithAttributes(attribute.String("reason", "camera queue full")))
+		s.logger.Warn("movimiento descartado, cola de cámara llena", "motion_id", motionID)
+	}
 }
 
 // CAMERA CAPTURE: Solo cuando hay movimiento (camera_capture con motion_id)
-func (s *USBHardwareSimulator) simulateWebcamCapture() {
+func (s *USBHardwareSimulator) simulateWebcamCapture(stop <-chan struct{}) {
 	ticker := time.NewTicker(800 * time.Millisecond)
 	defer ticker.Stop()
 
 	var currentMotionID string
+	var captureCtx context.Context
+	var captureSpan trace.Span
 
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stop:
+			if captureSpan != nil {
+				captureSpan.AddEvent("capture.cancelled")
+				captureSpan.End()
+			}
 			return
 
-		case motionID := <-s.motionChan:
-			currentMotionID = motionID
-			log.Printf("📸 Cámara lista para capturar - Motion ID recibido: %s", motionID)
+		case event := <-s.motionChan:
+			// Un movimiento nuevo reemplaza al que todavía no se capturó
+			if captureSpan != nil {
+				captureSpan.AddEvent("capture.superseded", trace.WithAttributes(attribute.String("motion.next_id", event.id)))
+				captureSpan.End()
+			}
+			currentMotionID = event.id
+			captureCtx, captureSpan = tracer.Start(event.ctx, "camera.capture", trace.WithAttributes(
+				attribute.String("motion.id", event.id),
+			))
+			s.logger.Debug("cámara lista para capturar", "motion_id", event.id)
 
 		case <-ticker.C:
 			if currentMotionID != "" {
-				photoURL := fmt.Sprintf("https://picsum.photos/seed/%d/640/480", time.Now().UnixNano())
+				photoURL := s.takePicture("capture", "USB-WEBCAM-RPi", "CAPTURE", true)
 				latency := 10 + rand.Intn(40)
 
 				reading := domain.CameraReading{
@@ -120,21 +258,27 @@ func (s *USBHardwareSimulator) simulateWebcamCapture() {
 					MotionID:  currentMotionID,
 					LatencyMs: latency,
 					Timestamp: time.Now(),
+					Metadata:  traceMetadata(captureCtx),
 				}
+				captureSpan.SetAttributes(
+					attribute.String("camera.image_path", photoURL),
+					attribute.Int("camera.latency_ms", latency),
+				)
 
 				s.mu.Lock()
 				s.lastCamera = reading
 				s.mu.Unlock()
 
 				if s.publisher != nil && s.publisher.IsConnected() {
-					log.Printf("📸 FOTO CAPTURADA - Motion ID: %s - URL: %s - Latencia: %dms",
-						currentMotionID, photoURL, latency)
+					s.logger.Info("foto capturada", "motion_id", currentMotionID, "url", photoURL, "latency_ms", latency)
 
-					if err := s.publisher.Publish("vigiltech/sensors/usb/camera", reading); err != nil {
-						log.Printf("ERROR publishing camera: %v", err)
+					if err := publishTraced(captureCtx, s.publisher, "vigiltech/sensors/usb/camera", reading); err != nil {
+						s.logger.Error("error publicando captura", "error", err)
 					}
 				}
 
+				captureSpan.End()
+				captureSpan = nil
 				currentMotionID = ""
 			}
 		}
@@ -142,16 +286,19 @@ func (s *USBHardwareSimulator) simulateWebcamCapture() {
 }
 
 // CAMERA STREAM: SIEMPRE envía imágenes cada 1 segundo (camera_stream sin motion_id)
-func (s *USBHardwareSimulator) simulateCameraStream() {
+func (s *USBHardwareSimulator) simulateCameraStream(stop <-chan struct{}) {
 	ticker := time.NewTicker(1000 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stop:
 			return
 		case <-ticker.C:
-			photoURL := fmt.Sprintf("https://picsum.photos/seed/%d/640/480", time.Now().UnixNano())
+			s.mu.RLock()
+			motion := s.lastMotion.MotionDetected
+			s.mu.RUnlock()
+			photoURL := s.takePicture("stream", "USB-WEBCAM-RPi-STREAM", "LIVE", motion)
 			latency := 5 + rand.Intn(15)
 
 			reading := domain.CameraStreamReading{
@@ -169,15 +316,58 @@ func (s *USBHardwareSimulator) simulateCameraStream() {
 
 			if s.publisher != nil && s.publisher.IsConnected() {
 				if err := s.publisher.Publish("vigiltech/sensors/usb/camera_stream", reading); err != nil {
-					log.Printf("ERROR publishing camera stream: %v", err)
+					s.logger.Error("error publicando stream", "error", err)
 				}
 			}
 		}
 	}
 }
 
+// takePicture retorna el ImagePath de una imagen nueva; sin cámara local, o si
+// la imagen no se pudo generar, usa una URL de picsum
+func (s *USBHardwareSimulator) takePicture(prefix, deviceID, label string, motion bool) string {
+	s.mu.RLock()
+	camera := s.camera
+	intensity := s.lastMotion.Intensity
+	s.mu.RUnlock()
+
+	now := time.Now()
+	if camera == nil {
+		return picsumURL(now)
+	}
+	path, err := camera.Capture(prefix, webcam.Frame{
+		Time:      now,
+		DeviceID:  deviceID,
+		Label:     label,
+		Motion:    motion,
+		Intensity: intensity,
+	})
+	if err != nil {
+		s.logger.Error("error generando imagen", "error", err)
+		return picsumURL(now)
+	}
+	return path
+}
+
+func picsumURL(t time.Time) string {
+	return fmt.Sprintf("https://picsum.photos/seed/%d/640/480", t.UnixNano())
+}
+
 func (s *USBHardwareSimulator) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
 	close(s.stopChan)
+	s.running = false
+}
+
+func (s *USBHardwareSimulator) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
 }
 
 func (s *USBHardwareSimulator) GetState() interface{} {
@@ -0,0 +1,29 @@
+package webcam
+
+// Calidad JPEG de las imágenes guardadas
+const defaultQuality = 80
+
+// Camera renderiza un frame y lo guarda en el Store
+type Camera struct {
+	renderer *Renderer
+	store    *Store
+}
+
+// NewCamera une un renderizador con un almacenamiento
+func NewCamera(renderer *Renderer, store *Store) *Camera {
+	return &Camera{renderer: renderer, store: store}
+}
+
+// Renderer retorna el renderizador de la cámara
+func (c *Camera) Renderer() *Renderer {
+	return c.renderer
+}
+
+// Capture genera la imagen y retorna el valor para ImagePath
+func (c *Camera) Capture(prefix string, f Frame) (string, error) {
+	data, err := c.renderer.RenderJPEG(f, defaultQuality)
+	if err != nil {
+		return "", err
+	}
+	return c.store.Save(prefix, f.Time, data)
+}
@@ -0,0 +1,185 @@
+package webcam
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+const mjpegBoundary = "vigiltechframe"
+
+// MotionSource es el PIR cuyo estado muestra el stream
+type MotionSource interface {
+	GetMotionReading() domain.MotionReading
+}
+
+// StreamConfig define la resolución, la tasa y la calidad del stream MJPEG
+type StreamConfig struct {
+	Width    int
+	Height   int
+	FPS      float64
+	Quality  int
+	DeviceID string
+}
+
+// DefaultStreamConfig retorna 640x480 a 10 fps
+func DefaultStreamConfig() StreamConfig {
+	return StreamConfig{
+		Width:    640,
+		Height:   480,
+		FPS:      10,
+		Quality:  70,
+		DeviceID: "USB-WEBCAM-RPi-STREAM",
+	}
+}
+
+// Stream sirve multipart/x-mixed-replace como una cámara IP. Los frames se
+// generan una sola vez para todos los clientes y solo mientras haya alguno.
+type Stream struct {
+	cfg      StreamConfig
+	renderer *Renderer
+	motion   MotionSource
+	mu       sync.Mutex
+	clients  map[chan []byte]struct{}
+	stop     chan struct{}
+	logger   *slog.Logger
+}
+
+// NewStream crea el handler del stream; motion puede ser nil (sin movimiento)
+func NewStream(cfg StreamConfig, motion MotionSource) *Stream {
+	def := DefaultStreamConfig()
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		cfg.Width, cfg.Height = def.Width, def.Height
+	}
+	if cfg.FPS <= 0 {
+		cfg.FPS = def.FPS
+	}
+	if cfg.Quality <= 0 || cfg.Quality > 100 {
+		cfg.Quality = def.Quality
+	}
+	if cfg.DeviceID == "" {
+		cfg.DeviceID = def.DeviceID
+	}
+	return &Stream{
+		cfg:      cfg,
+		renderer: NewRenderer(cfg.Width, cfg.Height),
+		motion:   motion,
+		clients:  make(map[chan []byte]struct{}),
+		logger:   logging.For("webcam"),
+	}
+}
+
+// ServeHTTP envía frames JPEG hasta que el cliente se desconecta
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming no soportado", http.StatusInternalServerError)
+		return
+	}
+
+	frames := s.subscribe()
+	defer s.unsubscribe(frames)
+	s.logger.Info("cliente MJPEG conectado", "remote", r.RemoteAddr)
+	defer s.logger.Info("cliente MJPEG desconectado", "remote", r.RemoteAddr)
+
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mjpegBoundary)
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Connection", "close")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case frame := <-frames:
+			if _, err := fmt.Fprintf(w, "--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n", mjpegBoundary, len(frame)); err != nil {
+				return
+			}
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			if _, err := w.Write([]byte("\r\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// subscribe registra un cliente y arranca el generador con el primero
+func (s *Stream) subscribe() chan []byte {
+	frames := make(chan []byte, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[frames] = struct{}{}
+	if s.stop == nil {
+		s.stop = make(chan struct{})
+		go s.produce(s.stop)
+	}
+	return frames
+}
+
+// unsubscribe quita el cliente y detiene el generador con el último
+func (s *Stream) unsubscribe(frames chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, frames)
+	if len(s.clients) == 0 && s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// produce renderiza a la tasa configurada y reparte el frame más reciente
+func (s *Stream) produce(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.cfg.FPS))
+	defer ticker.Stop()
+
+	for {
+		s.broadcast(s.frame(time.Now()))
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Stream) frame(now time.Time) []byte {
+	f := Frame{Time: now, DeviceID: s.cfg.DeviceID, Label: "LIVE"}
+	if s.motion != nil {
+		reading := s.motion.GetMotionReading()
+		f.Motion = reading.MotionDetected
+		f.Intensity = reading.Intensity
+	}
+	data, err := s.renderer.RenderJPEG(f, s.cfg.Quality)
+	if err != nil {
+		s.logger.Error("error codificando frame", "error", err)
+		return nil
+	}
+	return data
+}
+
+func (s *Stream) broadcast(frame []byte) {
+	if frame == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for frames := range s.clients {
+		// Un cliente lento se salta frames en lugar de atrasarse
+		select {
+		case <-frames:
+		default:
+		}
+		frames <- frame
+	}
+}
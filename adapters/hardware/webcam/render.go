@@ -0,0 +1,228 @@
+// Package webcam genera las imágenes de la cámara USB sin conexión a internet:
+// una vista fija del laboratorio con ruido de sensor, la hora y el ID del
+// dispositivo sobreimpresos y una silueta cuando el PIR detecta movimiento.
+package webcam
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math"
+	"sync"
+	"time"
+)
+
+// Frame describe lo que muestra una imagen
+type Frame struct {
+	Time     time.Time
+	DeviceID string
+	Label    string // por ejemplo CAPTURE o LIVE
+	Motion   bool
+	// Intensity (0-100) acerca la silueta: más intensidad, persona más cerca
+	Intensity float64
+}
+
+// Renderer dibuja frames de un tamaño fijo sobre un fondo precalculado
+type Renderer struct {
+	width, height int
+	background    *image.RGBA
+	mu            sync.Mutex
+	seed          uint32
+}
+
+// NewRenderer crea el renderizador para width x height píxeles
+func NewRenderer(width, height int) *Renderer {
+	r := &Renderer{width: width, height: height, seed: 2463534242}
+	r.background = r.drawRoom()
+	return r
+}
+
+// Size retorna la resolución de los frames
+func (r *Renderer) Size() (width, height int) {
+	return r.width, r.height
+}
+
+// Render dibuja un frame nuevo
+func (r *Renderer) Render(f Frame) *image.RGBA {
+	img := image.NewRGBA(r.background.Bounds())
+	copy(img.Pix, r.background.Pix)
+
+	if f.Motion {
+		r.drawFigure(img, f)
+	}
+	r.addNoise(img)
+	r.drawOverlay(img, f)
+	return img
+}
+
+// RenderJPEG dibuja el frame y lo codifica en JPEG
+func (r *Renderer) RenderJPEG(f Frame, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, r.Render(f), &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawRoom dibuja la pared, la ventana, el piso en perspectiva y dos mesas
+func (r *Renderer) drawRoom() *image.RGBA {
+	w, h := r.width, r.height
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	horizon := h * 45 / 100
+
+	fillRect(img, image.Rect(0, 0, w, horizon), color.RGBA{178, 186, 190, 255})
+	fillRect(img, image.Rect(0, horizon, w, h), color.RGBA{96, 92, 88, 255})
+	fillRect(img, image.Rect(0, horizon-h/80, w, horizon), color.RGBA{70, 70, 72, 255})
+
+	// Ventana con luz de día y luminaria
+	fillRect(img, image.Rect(w*62/100, h*8/100, w*88/100, h*32/100), color.RGBA{60, 62, 66, 255})
+	fillRect(img, image.Rect(w*63/100, h*9/100, w*87/100, h*31/100), color.RGBA{170, 205, 230, 255})
+	fillRect(img, image.Rect(w*75/100-1, h*9/100, w*75/100+1, h*31/100), color.RGBA{60, 62, 66, 255})
+	fillRect(img, image.Rect(w*20/100, 0, w*45/100, h*3/100), color.RGBA{235, 235, 225, 255})
+
+	// Juntas del piso hacia el punto de fuga
+	vx, vy := float64(w)/2, float64(horizon)
+	for i := -6; i <= 6; i++ {
+		x := float64(w)/2 + float64(i)*float64(w)/6
+		drawLine(img, vx, vy, x, float64(h), color.RGBA{84, 80, 77, 255})
+	}
+
+	// Mesas de laboratorio
+	table := color.RGBA{139, 90, 43, 255}
+	legs := color.RGBA{80, 55, 30, 255}
+	for _, t := range []struct{ x0, x1, y0, y1 float64 }{{0.05, 0.38, 0.58, 0.70}, {0.60, 0.95, 0.62, 0.76}} {
+		x0, x1 := int(t.x0*float64(w)), int(t.x1*float64(w))
+		y0, y1 := int(t.y0*float64(h)), int(t.y1*float64(h))
+		fillRect(img, image.Rect(x0+w/60, y1, x0+w/60+w/80, y1+h/8), legs)
+		fillRect(img, image.Rect(x1-w/60-w/80, y1, x1-w/60, y1+h/8), legs)
+		fillQuad(img, [4]image.Point{{x0 + w/30, y0}, {x1 - w/30, y0}, {x1, y1}, {x0, y1}}, table)
+	}
+	return img
+}
+
+// drawFigure dibuja una persona que cruza la escena de izquierda a derecha
+func (r *Renderer) drawFigure(img *image.RGBA, f Frame) {
+	w, h := r.width, r.height
+	size := 0.35 + 0.35*math.Max(0, math.Min(100, f.Intensity))/100
+	height := size * float64(h)
+
+	const crossing = 8 * time.Second
+	phase := float64(f.Time.UnixNano()%int64(crossing)) / float64(crossing)
+	cx := int((0.1 + 0.8*phase) * float64(w))
+	feet := int(float64(h)*0.45 + height*0.9)
+	if feet > h {
+		feet = h
+	}
+	top := feet - int(height)
+
+	body := color.RGBA{38, 40, 48, 255}
+	head := int(height * 0.09)
+	shoulders := int(height * 0.13)
+	fillCircle(img, cx, top+head, head, body)
+	fillRect(img, image.Rect(cx-shoulders, top+2*head, cx+shoulders, top+int(height*0.58)), body)
+
+	// Piernas alternadas según el paso
+	stride := int(height * 0.06 * math.Sin(phase*2*math.Pi*6))
+	fillQuad(img, [4]image.Point{{cx - shoulders, top + int(height*0.58)}, {cx - 1, top + int(height*0.58)}, {cx - 1 + stride, feet}, {cx - shoulders + stride, feet}}, body)
+	fillQuad(img, [4]image.Point{{cx + 1, top + int(height*0.58)}, {cx + shoulders, top + int(height*0.58)}, {cx + shoulders - stride, feet}, {cx + 1 - stride, feet}}, body)
+}
+
+// drawOverlay escribe el ID arriba, la hora abajo y la marca de movimiento
+func (r *Renderer) drawOverlay(img *image.RGBA, f Frame) {
+	scale := r.height / 240
+	if scale < 1 {
+		scale = 1
+	}
+	margin := 4 * scale
+	lineHeight := (glyphHeight + 4) * scale
+	shade := color.RGBA{0, 0, 0, 255}
+	text := color.RGBA{255, 255, 255, 255}
+
+	fillRect(img, image.Rect(0, 0, r.width, lineHeight), shade)
+	drawText(img, margin, 2*scale, scale, f.DeviceID, text)
+	if f.Label != "" {
+		drawText(img, r.width-margin-textWidth(f.Label, scale), 2*scale, scale, f.Label, text)
+	}
+
+	fillRect(img, image.Rect(0, r.height-lineHeight, r.width, r.height), shade)
+	drawText(img, margin, r.height-lineHeight+2*scale, scale, f.Time.Format("2006-01-02 15:04:05.000"), text)
+
+	if f.Motion {
+		label := "MOTION"
+		x := r.width - margin - textWidth(label, scale)
+		fillCircle(img, x-5*scale, r.height-lineHeight/2, 2*scale, color.RGBA{255, 40, 40, 255})
+		drawText(img, x, r.height-lineHeight+2*scale, scale, label, color.RGBA{255, 80, 80, 255})
+	}
+}
+
+// addNoise suma el grano del sensor con un xorshift barato
+func (r *Renderer) addNoise(img *image.RGBA) {
+	r.mu.Lock()
+	seed := r.seed
+	r.seed = seed*1664525 + 1013904223
+	r.mu.Unlock()
+
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		seed ^= seed << 13
+		seed ^= seed >> 17
+		seed ^= seed << 5
+		n := int(seed%13) - 6
+		for c := 0; c < 3; c++ {
+			v := int(img.Pix[i+c]) + n
+			if v < 0 {
+				v = 0
+			} else if v > 255 {
+				v = 255
+			}
+			img.Pix[i+c] = uint8(v)
+		}
+	}
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	draw.Draw(img, rect.Intersect(img.Bounds()), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+func fillCircle(img *image.RGBA, cx, cy, radius int, c color.RGBA) {
+	for y := -radius; y <= radius; y++ {
+		half := int(math.Sqrt(float64(radius*radius - y*y)))
+		fillRect(img, image.Rect(cx-half, cy+y, cx+half+1, cy+y+1), c)
+	}
+}
+
+// fillQuad rellena un cuadrilátero convexo con los vértices en orden
+func fillQuad(img *image.RGBA, pts [4]image.Point, c color.RGBA) {
+	minY, maxY := pts[0].Y, pts[0].Y
+	for _, p := range pts {
+		minY = min(minY, p.Y)
+		maxY = max(maxY, p.Y)
+	}
+	for y := minY; y <= maxY; y++ {
+		left, right := math.Inf(1), math.Inf(-1)
+		for i := range pts {
+			a, b := pts[i], pts[(i+1)%len(pts)]
+			if a.Y == b.Y || y < min(a.Y, b.Y) || y > max(a.Y, b.Y) {
+				continue
+			}
+			x := float64(a.X) + float64(y-a.Y)*float64(b.X-a.X)/float64(b.Y-a.Y)
+			left = math.Min(left, x)
+			right = math.Max(right, x)
+		}
+		if left <= right {
+			fillRect(img, image.Rect(int(left), y, int(right)+1, y+1), c)
+		}
+	}
+}
+
+func drawLine(img *image.RGBA, x0, y0, x1, y1 float64, c color.RGBA) {
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)))
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(max(steps, 1))
+		x, y := int(x0+t*(x1-x0)), int(y0+t*(y1-y0))
+		if image.Pt(x, y).In(img.Bounds()) {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
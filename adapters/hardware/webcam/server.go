@@ -0,0 +1,70 @@
+package webcam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"simulador-hard/logging"
+)
+
+// Server sirve las imágenes guardadas en /images/ y los endpoints agregados
+// con Handle, como el stream MJPEG
+type Server struct {
+	addr   string
+	dir    string
+	server *http.Server
+	mux    *http.ServeMux
+	logger *slog.Logger
+}
+
+// NewServer crea el servidor HTTP de la cámara para las imágenes de dir
+func NewServer(addr, dir string) *Server {
+	return &Server{
+		addr:   addr,
+		dir:    dir,
+		mux:    http.NewServeMux(),
+		logger: logging.For("webcam"),
+	}
+}
+
+// Handle registra un endpoint adicional antes de Start
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start abre el puerto y sirve en background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("webcam listen %s: %w", s.addr, err)
+	}
+
+	s.mux.Handle("/images/", http.StripPrefix("/images/", http.FileServer(http.Dir(s.dir))))
+	s.server = &http.Server{Handler: s.mux}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("error del servidor de la cámara", "error", err)
+		}
+	}()
+
+	s.logger.Info("imágenes de la cámara disponibles", "url", fmt.Sprintf("http://%s/images/", listener.Addr()))
+	return nil
+}
+
+// Stop detiene el servidor; los streams abiertos se cortan al vencer la espera
+func (s *Server) Stop() {
+	if s.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.server.Close()
+	}
+}
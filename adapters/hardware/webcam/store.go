@@ -0,0 +1,147 @@
+package webcam
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"simulador-hard/logging"
+)
+
+// DefaultMaxFiles es la cantidad de imágenes que se conservan por tipo
+const DefaultMaxFiles = 300
+
+// Store guarda las imágenes en un directorio y borra las más viejas
+type Store struct {
+	dir      string
+	baseURL  string
+	maxFiles int
+	mu       sync.Mutex
+	saved    map[string][]string // por prefijo, en orden de creación
+	logger   *slog.Logger
+}
+
+// NewStore crea el directorio si no existe. Con baseURL (por ejemplo
+// http://localhost:8082/images) ImagePath es una URL; sin ella, la ruta absoluta.
+// Las imágenes de ejecuciones anteriores cuentan para el límite de maxFiles.
+func NewStore(dir, baseURL string, maxFiles int) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("webcam: %w", err)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("webcam: %w", err)
+	}
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxFiles
+	}
+	s := &Store{
+		dir:      abs,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		maxFiles: maxFiles,
+		saved:    make(map[string][]string),
+		logger:   logging.For("webcam"),
+	}
+	if err := s.seed(); err != nil {
+		return nil, fmt.Errorf("webcam: %w", err)
+	}
+	return s, nil
+}
+
+// seed registra las imágenes <prefix>-<unix ms>.jpg que ya están en el
+// directorio, de la más vieja a la más nueva, y borra las que sobran
+func (s *Store) seed() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	type stored struct {
+		path string
+		ms   int64
+	}
+	found := make(map[string][]stored)
+	for _, e := range entries {
+		prefix, ms, ok := parseImageName(e.Name())
+		if !ok || e.IsDir() {
+			continue
+		}
+		found[prefix] = append(found[prefix], stored{filepath.Join(s.dir, e.Name()), ms})
+	}
+
+	for prefix, images := range found {
+		sort.Slice(images, func(i, j int) bool { return images[i].ms < images[j].ms })
+		for len(images) > s.maxFiles {
+			s.remove(images[0].path)
+			images = images[1:]
+		}
+		paths := make([]string, len(images))
+		for i, im := range images {
+			paths[i] = im.path
+		}
+		s.saved[prefix] = paths
+	}
+	return nil
+}
+
+// parseImageName separa el prefijo y los milisegundos de un nombre de Save
+func parseImageName(name string) (string, int64, bool) {
+	base, ok := strings.CutSuffix(name, ".jpg")
+	if !ok {
+		return "", 0, false
+	}
+	i := strings.LastIndexByte(base, '-')
+	if i <= 0 {
+		return "", 0, false
+	}
+	ms, err := strconv.ParseInt(base[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return base[:i], ms, true
+}
+
+// Dir retorna el directorio absoluto de las imágenes
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save escribe <prefix>-<unix ms>.jpg y retorna su URL o ruta
+func (s *Store) Save(prefix string, t time.Time, data []byte) (string, error) {
+	name := fmt.Sprintf("%s-%d.jpg", prefix, t.UnixMilli())
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("webcam: %w", err)
+	}
+
+	s.mu.Lock()
+	files := append(s.saved[prefix], path)
+	var expired []string
+	if len(files) > s.maxFiles {
+		expired = files[:len(files)-s.maxFiles]
+		files = files[len(files)-s.maxFiles:]
+	}
+	s.saved[prefix] = files
+	s.mu.Unlock()
+
+	for _, old := range expired {
+		s.remove(old)
+	}
+
+	if s.baseURL == "" {
+		return path, nil
+	}
+	return s.baseURL + "/" + name, nil
+}
+
+func (s *Store) remove(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("error borrando imagen vieja", "path", path, "error", err)
+	}
+}
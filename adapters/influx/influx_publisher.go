@@ -0,0 +1,210 @@
+package influx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"simulador-hard/logging"
+)
+
+// Config define el destino de las líneas: un archivo local, un endpoint HTTP o ambos.
+// URL es el endpoint de escritura completo, por ejemplo
+// http://localhost:8086/api/v2/write?org=vigiltech&bucket=sim&precision=ns
+type Config struct {
+	FilePath      string
+	URL           string
+	Token         string
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// InfluxPublisher implementa un publicador que exporta en line protocol
+type InfluxPublisher struct {
+	cfg      Config
+	client   *http.Client
+	mu       sync.Mutex
+	file     *os.File
+	batch    bytes.Buffer
+	lines    int
+	flushCh  chan struct{}
+	stopChan chan struct{}
+	doneChan chan struct{}
+	open     bool
+	logger   *slog.Logger
+}
+
+// NewInfluxPublisher crea un nuevo exportador de InfluxDB
+func NewInfluxPublisher(cfg Config) *InfluxPublisher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	return &InfluxPublisher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logging.For("influx"),
+	}
+}
+
+// Connect abre el archivo destino y arranca el envío periódico por HTTP
+func (p *InfluxPublisher) Connect() error {
+	if p.cfg.FilePath == "" && p.cfg.URL == "" {
+		return fmt.Errorf("influx: se requiere FilePath o URL")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cfg.FilePath != "" {
+		file, err := os.OpenFile(p.cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		p.file = file
+	}
+
+	if p.cfg.URL != "" {
+		p.flushCh = make(chan struct{}, 1)
+		p.stopChan = make(chan struct{})
+		p.doneChan = make(chan struct{})
+		go p.flushLoop()
+	}
+
+	p.open = true
+	p.logger.Info("exportador InfluxDB activo", "file", p.cfg.FilePath, "url", p.cfg.URL)
+	return nil
+}
+
+// Publish convierte la lectura a line protocol y la escribe o encola
+func (p *InfluxPublisher) Publish(topic string, payload interface{}) error {
+	// Las lecturas sin measurement (tramas crudas, PMS5003 extendida) no se exportan
+	line, ok := ToLine(payload)
+	if !ok {
+		p.logger.Debug("lectura sin measurement", "topic", topic, "type", fmt.Sprintf("%T", payload))
+		return nil
+	}
+
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return nil
+	}
+
+	if p.file != nil {
+		if _, err := p.file.WriteString(line + "\n"); err != nil {
+			p.mu.Unlock()
+			return err
+		}
+	}
+
+	if p.cfg.URL != "" {
+		p.batch.WriteString(line)
+		p.batch.WriteByte('\n')
+		p.lines++
+		if p.lines >= p.cfg.BatchSize {
+			select {
+			case p.flushCh <- struct{}{}:
+			default:
+			}
+		}
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+// IsConnected indica si el exportador está activo
+func (p *InfluxPublisher) IsConnected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.open
+}
+
+// Disconnect envía las líneas pendientes y cierra el archivo
+func (p *InfluxPublisher) Disconnect() {
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return
+	}
+	p.open = false
+	p.mu.Unlock()
+
+	if p.stopChan != nil {
+		close(p.stopChan)
+		<-p.doneChan
+		if err := p.flush(); err != nil {
+			p.logger.Error("error escribiendo en InfluxDB", "error", err)
+		}
+	}
+
+	p.mu.Lock()
+	if p.file != nil {
+		p.file.Close()
+		p.file = nil
+	}
+	p.mu.Unlock()
+
+	p.logger.Info("exportador InfluxDB detenido")
+}
+
+func (p *InfluxPublisher) flushLoop() {
+	defer close(p.doneChan)
+
+	ticker := time.NewTicker(p.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopChan:
+			return
+		case <-ticker.C:
+		case <-p.flushCh:
+		}
+		if err := p.flush(); err != nil {
+			p.logger.Error("error escribiendo en InfluxDB", "error", err)
+		}
+	}
+}
+
+// flush envía el lote acumulado al endpoint de escritura
+func (p *InfluxPublisher) flush() error {
+	p.mu.Lock()
+	if p.lines == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	body := make([]byte, p.batch.Len())
+	copy(body, p.batch.Bytes())
+	p.batch.Reset()
+	p.lines = 0
+	p.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodPost, p.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if p.cfg.Token != "" {
+		req.Header.Set("Authorization", "Token "+p.cfg.Token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("influx write status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
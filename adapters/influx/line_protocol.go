@@ -0,0 +1,137 @@
+package influx
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"simulador-hard/domain"
+)
+
+var (
+	tagEscaper    = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// field es un par clave/valor ya formateado para line protocol
+type field struct {
+	key   string
+	value string
+}
+
+// ToLine convierte una lectura del dominio en una línea de InfluxDB.
+// La measurement es la tabla de la lectura, los tags system_id/sensor_id
+// y el timestamp se expresa en nanosegundos. Los campos NaN o infinitos se
+// omiten porque line protocol no puede representarlos.
+func ToLine(payload interface{}) (string, bool) {
+	switch r := payload.(type) {
+	case domain.GasReading:
+		return formatLine(domain.TableGasSensor, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"lpg", float(r.LPG)},
+			field{"co", float(r.CO)},
+			field{"smoke", float(r.Smoke)},
+		), true
+	case domain.ParticleReading:
+		return formatLine(domain.TableParticleSensor, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"pm1_0", float(r.PM10)},
+			field{"pm2_5", float(r.PM25)},
+			field{"pm10", float(r.PM100)},
+		), true
+	case domain.MotionReading:
+		return formatLine(domain.TableMotionSensors, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"motion_detected", strconv.FormatBool(r.MotionDetected)},
+			field{"intensity", float(r.Intensity)},
+		), true
+	case domain.CameraReading:
+		return formatLine(domain.TableCameraCapture, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"image_path", quote(r.ImagePath)},
+			field{"motion_id", quote(r.MotionID)},
+			field{"latency_ms", integer(r.LatencyMs)},
+		), true
+	case domain.CameraStreamReading:
+		return formatLine(domain.TableCameraStream, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"image_path", quote(r.ImagePath)},
+			field{"latency_ms", integer(r.LatencyMs)},
+		), true
+	case domain.ClimateReading:
+		return formatLine(domain.TableClimateSensor, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"temperature", float(r.Temperature)},
+			field{"humidity", float(r.Humidity)},
+		), true
+	case domain.FlameReading:
+		return formatLine(domain.TableFlameSensor, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"flame_detected", strconv.FormatBool(r.FlameDetected)},
+			field{"ir_level", integer(r.IRLevel)},
+		), true
+	case domain.SoundReading:
+		return formatLine(domain.TableSoundSensor, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"level_db", float(r.LevelDB)},
+		), true
+	case domain.CO2Reading:
+		return formatLine(domain.TableCO2Sensor, r.SystemID, r.SensorID, r.Timestamp,
+			field{"id", quote(r.ID)},
+			field{"co2", float(r.CO2)},
+			field{"warming", strconv.FormatBool(r.Warming)},
+		), true
+	}
+	return "", false
+}
+
+func formatLine(measurement string, systemID int, sensorID string, ts time.Time, fields ...field) string {
+	var b strings.Builder
+	b.WriteString(tagEscaper.Replace(measurement))
+	b.WriteString(",system_id=")
+	b.WriteString(strconv.Itoa(systemID))
+	if sensorID != "" {
+		b.WriteString(",sensor_id=")
+		b.WriteString(tagEscaper.Replace(sensorID))
+	}
+
+	first := true
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if first {
+			b.WriteByte(' ')
+			first = false
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(tagEscaper.Replace(f.key))
+		b.WriteByte('=')
+		b.WriteString(f.value)
+	}
+
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatInt(ts.UnixNano(), 10))
+	return b.String()
+}
+
+// float retorna "" para NaN e infinitos, que formatLine omite
+func float(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func integer(v int) string {
+	return strconv.Itoa(v) + "i"
+}
+
+func quote(s string) string {
+	return `"` + stringEscaper.Replace(s) + `"`
+}
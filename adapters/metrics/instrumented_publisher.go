@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"time"
+
+	"simulador-hard/ports"
+)
+
+// InstrumentedPublisher envuelve un publicador y registra sus métricas
+type InstrumentedPublisher struct {
+	next    ports.DataPublisher
+	metrics *Metrics
+}
+
+// NewInstrumentedPublisher crea un decorador de métricas sobre next
+func NewInstrumentedPublisher(next ports.DataPublisher, metrics *Metrics) *InstrumentedPublisher {
+	return &InstrumentedPublisher{next: next, metrics: metrics}
+}
+
+// Publish publica en el publicador interno midiendo latencia y errores
+func (p *InstrumentedPublisher) Publish(topic string, payload interface{}) error {
+	p.metrics.observeReading(payload)
+
+	p.metrics.inFlight.Inc()
+	start := time.Now()
+	err := p.next.Publish(topic, payload)
+	p.metrics.publishDuration.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+	p.metrics.inFlight.Dec()
+
+	if err != nil {
+		p.metrics.publishErrors.WithLabelValues(topic).Inc()
+		return err
+	}
+	p.metrics.published.WithLabelValues(topic).Inc()
+	return nil
+}
+
+// IsConnected delega en el publicador interno
+func (p *InstrumentedPublisher) IsConnected() bool {
+	return p.next.IsConnected()
+}
+
+// Connect delega en el publicador interno
+func (p *InstrumentedPublisher) Connect() error {
+	return p.next.Connect()
+}
+
+// Disconnect delega en el publicador interno
+func (p *InstrumentedPublisher) Disconnect() {
+	p.next.Disconnect()
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"simulador-hard/domain"
+)
+
+// Metrics agrupa los collectors Prometheus del simulador
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	sensorValue     *prometheus.GaugeVec
+	published       *prometheus.CounterVec
+	publishErrors   *prometheus.CounterVec
+	publishDuration *prometheus.HistogramVec
+	inFlight        prometheus.Gauge
+}
+
+// NewMetrics crea y registra todos los collectors
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		sensorValue: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "vigiltech_sensor_value",
+			Help: "Último valor publicado por cada sensor.",
+		}, []string{"mesa", "sensor", "field"}),
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vigiltech_published_messages_total",
+			Help: "Mensajes publicados por topic.",
+		}, []string{"topic"}),
+		publishErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vigiltech_publish_errors_total",
+			Help: "Errores al publicar por topic.",
+		}, []string{"topic"}),
+		publishDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "vigiltech_publish_duration_seconds",
+			Help:    "Latencia de cada publicación.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"topic"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "vigiltech_publish_in_flight",
+			Help: "Publicaciones en curso que aún no retornan.",
+		}),
+	}
+
+	m.Registry.MustRegister(
+		m.sensorValue,
+		m.published,
+		m.publishErrors,
+		m.publishDuration,
+		m.inFlight,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// RegisterConnectionState expone el estado de conexión de un publicador (1 conectado, 0 no)
+func (m *Metrics) RegisterConnectionState(name string, isConnected func() bool) {
+	m.Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "vigiltech_connection_up",
+		Help:        "Estado de conexión de los adaptadores de salida.",
+		ConstLabels: prometheus.Labels{"adapter": name},
+	}, func() float64 {
+		if isConnected() {
+			return 1
+		}
+		return 0
+	}))
+}
+
+// observeReading actualiza los gauges con los valores de la lectura
+func (m *Metrics) observeReading(payload interface{}) {
+	switch r := payload.(type) {
+	case domain.GasReading:
+		mesa := strconv.Itoa(r.SystemID)
+		m.sensorValue.WithLabelValues(mesa, "gas", "lpg").Set(r.LPG)
+		m.sensorValue.WithLabelValues(mesa, "gas", "co").Set(r.CO)
+		m.sensorValue.WithLabelValues(mesa, "gas", "smoke").Set(r.Smoke)
+	case domain.ParticleReading:
+		mesa := strconv.Itoa(r.SystemID)
+		m.sensorValue.WithLabelValues(mesa, "particles", "pm1_0").Set(r.PM10)
+		m.sensorValue.WithLabelValues(mesa, "particles", "pm2_5").Set(r.PM25)
+		m.sensorValue.WithLabelValues(mesa, "particles", "pm10").Set(r.PM100)
+	case domain.ClimateReading:
+		mesa := strconv.Itoa(r.SystemID)
+		m.sensorValue.WithLabelValues(mesa, "climate", "temperature").Set(r.Temperature)
+		m.sensorValue.WithLabelValues(mesa, "climate", "humidity").Set(r.Humidity)
+	case domain.FlameReading:
+		mesa := strconv.Itoa(r.SystemID)
+		detected := 0.0
+		if r.FlameDetected {
+			detected = 1
+		}
+		m.sensorValue.WithLabelValues(mesa, "flame", "flame_detected").Set(detected)
+		m.sensorValue.WithLabelValues(mesa, "flame", "ir_level").Set(float64(r.IRLevel))
+	case domain.SoundReading:
+		m.sensorValue.WithLabelValues(strconv.Itoa(r.SystemID), "sound", "level_db").Set(r.LevelDB)
+	case domain.CO2Reading:
+		m.sensorValue.WithLabelValues(strconv.Itoa(r.SystemID), "co2", "co2").Set(r.CO2)
+	case domain.MotionReading:
+		mesa := strconv.Itoa(r.SystemID)
+		detected := 0.0
+		if r.MotionDetected {
+			detected = 1
+		}
+		m.sensorValue.WithLabelValues(mesa, "motion", "motion_detected").Set(detected)
+		m.sensorValue.WithLabelValues(mesa, "motion", "intensity").Set(r.Intensity)
+	case domain.CameraReading:
+		m.sensorValue.WithLabelValues(strconv.Itoa(r.SystemID), "camera", "latency_ms").Set(float64(r.LatencyMs))
+	case domain.CameraStreamReading:
+		m.sensorValue.WithLabelValues(strconv.Itoa(r.SystemID), "camera_stream", "latency_ms").Set(float64(r.LatencyMs))
+	}
+}
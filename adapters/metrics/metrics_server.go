@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"simulador-hard/logging"
+)
+
+// MetricsServer expone /metrics por HTTP
+type MetricsServer struct {
+	addr    string
+	metrics *Metrics
+	server  *http.Server
+	mux     *http.ServeMux
+	logger  *slog.Logger
+}
+
+// NewMetricsServer crea un nuevo servidor de métricas
+func NewMetricsServer(addr string, metrics *Metrics) *MetricsServer {
+	return &MetricsServer{
+		addr:    addr,
+		metrics: metrics,
+		mux:     http.NewServeMux(),
+		logger:  logging.For("metrics"),
+	}
+}
+
+// Handle registra un endpoint adicional (por ejemplo /debug/log) antes de Start
+func (s *MetricsServer) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start abre el puerto y sirve las métricas en background
+func (s *MetricsServer) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("metrics listen %s: %w", s.addr, err)
+	}
+
+	s.mux.Handle("/metrics", promhttp.HandlerFor(s.metrics.Registry, promhttp.HandlerOpts{}))
+	s.server = &http.Server{Handler: s.mux}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("error del servidor de métricas", "error", err)
+		}
+	}()
+
+	s.logger.Info("métricas Prometheus disponibles", "url", fmt.Sprintf("http://%s/metrics", listener.Addr()))
+	return nil
+}
+
+// Stop detiene el servidor de métricas
+func (s *MetricsServer) Stop() {
+	if s.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	s.server.Shutdown(ctx)
+}
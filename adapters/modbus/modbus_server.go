@@ -0,0 +1,238 @@
+package modbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"simulador-hard/logging"
+)
+
+// Códigos de función soportados; el servidor es de solo lectura
+const (
+	funcReadDiscreteInputs   = 0x02
+	funcReadHoldingRegisters = 0x03
+	funcReadInputRegisters   = 0x04
+)
+
+// Códigos de excepción Modbus
+const (
+	exceptionIllegalFunction    = 0x01
+	exceptionIllegalDataAddress = 0x02
+	exceptionIllegalDataValue   = 0x03
+)
+
+const (
+	mbapHeaderSize  = 7
+	maxPDUSize      = 253
+	maxReadBits     = 2000
+	maxReadRegister = 125
+	idleTimeout     = 2 * time.Minute
+)
+
+// ModbusServer expone las lecturas como registros Modbus TCP.
+// Implementa ports.DataPublisher para mantener actualizada la imagen de registros.
+type ModbusServer struct {
+	addr     string
+	mu       sync.RWMutex
+	bank     *registerBank
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	running  bool
+	logger   *slog.Logger
+}
+
+// NewModbusServer crea un servidor con un bloque de registros por mesa
+func NewModbusServer(addr string, mesas int) *ModbusServer {
+	return &ModbusServer{
+		addr:   addr,
+		bank:   newRegisterBank(mesas),
+		conns:  make(map[net.Conn]struct{}),
+		logger: logging.For("modbus"),
+	}
+}
+
+// Connect abre el puerto y empieza a aceptar clientes
+func (s *ModbusServer) Connect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("modbus listen %s: %w", s.addr, err)
+	}
+	s.listener = listener
+	s.running = true
+
+	go s.acceptLoop(listener)
+
+	s.logger.Info("servidor Modbus TCP escuchando", "addr", listener.Addr().String())
+	return nil
+}
+
+// Publish actualiza los registros de la lectura recibida
+func (s *ModbusServer) Publish(topic string, payload interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.bank.apply(payload) {
+		s.logger.Debug("lectura sin registros asignados", "topic", topic)
+	}
+	return nil
+}
+
+// IsConnected indica si el servidor está aceptando clientes
+func (s *ModbusServer) IsConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// Disconnect cierra el puerto y las conexiones abiertas
+func (s *ModbusServer) Disconnect() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	listener := s.listener
+	conns := s.conns
+	s.conns = make(map[net.Conn]struct{})
+	s.mu.Unlock()
+
+	listener.Close()
+	for conn := range conns {
+		conn.Close()
+	}
+	s.logger.Info("servidor Modbus TCP detenido")
+}
+
+func (s *ModbusServer) acceptLoop(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.logger.Error("error aceptando cliente Modbus", "error", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		if !s.running {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go s.serve(conn)
+	}
+}
+
+// serve atiende las peticiones de un cliente hasta que cierre la conexión
+func (s *ModbusServer) serve(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	s.logger.Debug("cliente Modbus conectado", "remote", conn.RemoteAddr().String())
+
+	header := make([]byte, mbapHeaderSize)
+	for {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+
+		// MBAP: transacción(2) protocolo(2) longitud(2) unidad(1)
+		protocol := binary.BigEndian.Uint16(header[2:4])
+		length := int(binary.BigEndian.Uint16(header[4:6]))
+		if protocol != 0 || length < 2 || length-1 > maxPDUSize {
+			s.logger.Warn("trama Modbus inválida", "remote", conn.RemoteAddr().String(), "protocol", protocol, "length", length)
+			return
+		}
+
+		pdu := make([]byte, length-1)
+		if _, err := io.ReadFull(conn, pdu); err != nil {
+			return
+		}
+
+		resp := s.handle(pdu)
+
+		out := make([]byte, mbapHeaderSize+len(resp))
+		copy(out, header[:4])
+		binary.BigEndian.PutUint16(out[4:6], uint16(len(resp)+1))
+		out[6] = header[6]
+		copy(out[mbapHeaderSize:], resp)
+
+		if _, err := conn.Write(out); err != nil {
+			return
+		}
+	}
+}
+
+// handle procesa un PDU y retorna la respuesta o la excepción correspondiente
+func (s *ModbusServer) handle(pdu []byte) []byte {
+	function := pdu[0]
+	switch function {
+	case funcReadDiscreteInputs, funcReadHoldingRegisters, funcReadInputRegisters:
+	default:
+		return exception(function, exceptionIllegalFunction)
+	}
+
+	if len(pdu) != 5 {
+		return exception(function, exceptionIllegalDataValue)
+	}
+	start := int(binary.BigEndian.Uint16(pdu[1:3]))
+	quantity := int(binary.BigEndian.Uint16(pdu[3:5]))
+
+	limit := maxReadRegister
+	if function == funcReadDiscreteInputs {
+		limit = maxReadBits
+	}
+	if quantity < 1 || quantity > limit {
+		return exception(function, exceptionIllegalDataValue)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if start+quantity > len(s.bank.registers) {
+		return exception(function, exceptionIllegalDataAddress)
+	}
+
+	if function == funcReadDiscreteInputs {
+		data := make([]byte, (quantity+7)/8)
+		for i := 0; i < quantity; i++ {
+			if s.bank.discretes[start+i] {
+				data[i/8] |= 1 << (i % 8)
+			}
+		}
+		return append([]byte{function, byte(len(data))}, data...)
+	}
+
+	resp := make([]byte, 2+quantity*2)
+	resp[0] = function
+	resp[1] = byte(quantity * 2)
+	for i := 0; i < quantity; i++ {
+		binary.BigEndian.PutUint16(resp[2+i*2:], s.bank.registers[start+i])
+	}
+	return resp
+}
+
+func exception(function, code byte) []byte {
+	return []byte{function | 0x80, code}
+}
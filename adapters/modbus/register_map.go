@@ -0,0 +1,158 @@
+package modbus
+
+import (
+	"math"
+	"time"
+
+	"simulador-hard/domain"
+)
+
+// Mapa de registros (direcciones base 0, iguales para holding 0x03 e input 0x04).
+// Los valores de concentración se escalan x10 (ppm o µg/m³ con un decimal) y se
+// saturan en 65535. Las marcas de tiempo son segundos Unix en dos registros (alto, bajo).
+//
+// Bloque 0: sensores USB (system_id 0)
+//
+//	0      PIR movimiento detectado (0/1)
+//	1      PIR intensidad x10 (%)
+//	2-3    PIR timestamp
+//
+// Bloque de la mesa N, base N*100:
+//
+//	+0     LPG x10 (ppm)
+//	+1     CO x10 (ppm)
+//	+2     Humo x10 (ppm)
+//	+3-4   Gas timestamp
+//	+10    PM1.0 x10 (µg/m³)
+//	+11    PM2.5 x10 (µg/m³)
+//	+12    PM10 x10 (µg/m³)
+//	+13-14 Partículas timestamp
+//	+20    Alertas: bit0 LPG, bit1 CO, bit2 humo, bit3 PM2.5
+//
+// Entradas discretas (0x02):
+//
+//	0          PIR movimiento detectado
+//	N*100+0..3 Alertas de la mesa N (LPG, CO, humo, PM2.5)
+//
+// Las direcciones sin uso dentro del rango leen 0; fuera de (mesas+1)*100
+// se responde con la excepción 02 (dirección ilegal).
+const (
+	BlockSize = 100
+
+	RegPIRDetected  = 0
+	RegPIRIntensity = 1
+	RegPIRTimestamp = 2
+
+	RegLPG               = 0
+	RegCO                = 1
+	RegSmoke             = 2
+	RegGasTimestamp      = 3
+	RegPM1               = 10
+	RegPM25              = 11
+	RegPM10              = 12
+	RegParticleTimestamp = 13
+	RegAlerts            = 20
+)
+
+// Bits del registro de alertas y entradas discretas relativas al bloque de la mesa
+const (
+	AlertLPG = iota
+	AlertCO
+	AlertSmoke
+	AlertPM25
+)
+
+// registerBank guarda la imagen de registros que leen los clientes
+type registerBank struct {
+	registers []uint16
+	discretes []bool
+}
+
+func newRegisterBank(mesas int) *registerBank {
+	size := (mesas + 1) * BlockSize
+	return &registerBank{
+		registers: make([]uint16, size),
+		discretes: make([]bool, size),
+	}
+}
+
+// apply actualiza los registros con una lectura; retorna false si no tiene mapeo
+func (b *registerBank) apply(payload interface{}) bool {
+	switch r := payload.(type) {
+	case domain.MotionReading:
+		b.registers[RegPIRDetected] = boolRegister(r.MotionDetected)
+		b.registers[RegPIRIntensity] = scaled(r.Intensity)
+		b.setTimestamp(RegPIRTimestamp, r.Timestamp)
+		b.discretes[RegPIRDetected] = r.MotionDetected
+		return true
+
+	case domain.GasReading:
+		base, ok := b.base(r.SystemID)
+		if !ok {
+			return false
+		}
+		b.registers[base+RegLPG] = scaled(r.LPG)
+		b.registers[base+RegCO] = scaled(r.CO)
+		b.registers[base+RegSmoke] = scaled(r.Smoke)
+		b.setTimestamp(base+RegGasTimestamp, r.Timestamp)
+		b.setAlert(base, AlertLPG, r.LPG > domain.GasAlertThreshold)
+		b.setAlert(base, AlertCO, r.CO > domain.GasAlertThreshold)
+		b.setAlert(base, AlertSmoke, r.Smoke > domain.GasAlertThreshold)
+		return true
+
+	case domain.ParticleReading:
+		base, ok := b.base(r.SystemID)
+		if !ok {
+			return false
+		}
+		b.registers[base+RegPM1] = scaled(r.PM10)
+		b.registers[base+RegPM25] = scaled(r.PM25)
+		b.registers[base+RegPM10] = scaled(r.PM100)
+		b.setTimestamp(base+RegParticleTimestamp, r.Timestamp)
+		b.setAlert(base, AlertPM25, r.HasAlert())
+		return true
+	}
+	return false
+}
+
+func (b *registerBank) base(mesa int) (int, bool) {
+	base := mesa * BlockSize
+	if mesa < 1 || base+BlockSize > len(b.registers) {
+		return 0, false
+	}
+	return base, true
+}
+
+func (b *registerBank) setTimestamp(addr int, t time.Time) {
+	secs := uint32(t.Unix())
+	b.registers[addr] = uint16(secs >> 16)
+	b.registers[addr+1] = uint16(secs)
+}
+
+func (b *registerBank) setAlert(base, bit int, active bool) {
+	mask := uint16(1) << bit
+	if active {
+		b.registers[base+RegAlerts] |= mask
+	} else {
+		b.registers[base+RegAlerts] &^= mask
+	}
+	b.discretes[base+bit] = active
+}
+
+func scaled(v float64) uint16 {
+	v = math.Round(v * 10)
+	if v <= 0 || math.IsNaN(v) {
+		return 0
+	}
+	if v >= math.MaxUint16 {
+		return math.MaxUint16
+	}
+	return uint16(v)
+}
+
+func boolRegister(v bool) uint16 {
+	if v {
+		return 1
+	}
+	return 0
+}
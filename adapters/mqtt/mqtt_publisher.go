@@ -1,18 +1,25 @@
 package mqtt
 
 import (
-	"encoding/json"
-	"log"
+	"log/slog"
+	"sync"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
 )
 
-// MQTTPublisher implementa el adaptador MQTT
+// MQTTPublisher implementa el adaptador MQTT. mu protege client, connected y
+// listener: los escenarios pueden reconectar mientras los sensores publican.
 type MQTTPublisher struct {
+	mu        sync.RWMutex
 	client    mqtt.Client
 	broker    string
 	clientID  string
 	connected bool
+	listener  func(connected bool, err error)
+	logger    *slog.Logger
 }
 
 // NewMQTTPublisher crea un nuevo publicador MQTT
@@ -20,11 +27,16 @@ func NewMQTTPublisher(broker, clientID string) *MQTTPublisher {
 	return &MQTTPublisher{
 		broker:   broker,
 		clientID: clientID,
+		logger:   logging.For("mqtt"),
 	}
 }
 
 // Connect establece conexión con el broker MQTT
 func (p *MQTTPublisher) Connect() error {
+	if p.IsConnected() {
+		return nil
+	}
+
 	opts := mqtt.NewClientOptions()
 	opts.AddBroker(p.broker)
 	opts.SetClientID(p.clientID)
@@ -32,61 +44,103 @@ func (p *MQTTPublisher) Connect() error {
 	opts.SetAutoReconnect(true)
 
 	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
-		log.Printf("Conexión MQTT perdida: %v", err)
-		p.connected = false
+		p.logger.Warn("conexión MQTT perdida", "broker", p.broker, "error", err)
+		p.setConnected(false)
+		p.notifyConnection(false, err)
 	})
 
 	opts.SetOnConnectHandler(func(client mqtt.Client) {
-		log.Println("Conectado al broker MQTT")
-		p.connected = true
+		p.logger.Info("conectado al broker MQTT", "broker", p.broker)
+		p.setConnected(true)
+		p.notifyConnection(true, nil)
 	})
 
-	p.client = mqtt.NewClient(opts)
+	client := mqtt.NewClient(opts)
 
-	token := p.client.Connect()
+	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
 		return token.Error()
 	}
 
+	p.mu.Lock()
+	p.client = client
 	p.connected = true
+	p.mu.Unlock()
 	return nil
 }
 
+func (p *MQTTPublisher) setConnected(connected bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.connected = connected
+}
+
+// SetConnectionListener registra un callback para los cambios de conexión
+func (p *MQTTPublisher) SetConnectionListener(listener func(connected bool, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listener = listener
+}
+
+func (p *MQTTPublisher) notifyConnection(connected bool, err error) {
+	p.mu.RLock()
+	listener := p.listener
+	p.mu.RUnlock()
+	if listener != nil {
+		listener(connected, err)
+	}
+}
+
 // Publish publica un mensaje en un topic
 func (p *MQTTPublisher) Publish(topic string, payload interface{}) error {
-	if !p.IsConnected() {
+	p.mu.RLock()
+	client, connected := p.client, p.connected
+	p.mu.RUnlock()
+	if !connected || client == nil || !client.IsConnected() {
 		return nil // Silenciar si no está conectado
 	}
 
-	// Serializar payload a JSON
-	data, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf("MQTT marshal error topic=%s: %v", topic, err)
-		return err
+	// Serializar payload a JSON; los payloads binarios (tramas crudas) van tal cual
+	data, ok := payload.([]byte)
+	if !ok {
+		var err error
+		data, err = domain.MarshalReading(payload)
+		if err != nil {
+			p.logger.Error("error serializando payload", "topic", topic, "error", err)
+			return err
+		}
 	}
 
 	// Publicar con QoS 1
-	token := p.client.Publish(topic, 1, false, data)
+	token := client.Publish(topic, 1, false, data)
 	token.Wait()
 	if token.Error() != nil {
-		log.Printf("MQTT publish error topic=%s: %v", topic, token.Error())
+		p.logger.Error("error publicando", "topic", topic, "error", token.Error())
 		return token.Error()
 	}
 
-	log.Printf("MQTT published topic=%s len=%d", topic, len(data))
+	p.logger.Debug("mensaje publicado", "topic", topic, "len", len(data))
 	return nil
 }
 
 // IsConnected verifica si está conectado
 func (p *MQTTPublisher) IsConnected() bool {
-	return p.connected && p.client != nil && p.client.IsConnected()
+	p.mu.RLock()
+	client, connected := p.client, p.connected
+	p.mu.RUnlock()
+	return connected && client != nil && client.IsConnected()
 }
 
 // Disconnect cierra la conexión MQTT
 func (p *MQTTPublisher) Disconnect() {
-	if p.client != nil && p.client.IsConnected() {
-		p.client.Disconnect(250)
-		p.connected = false
-		log.Println("Desconectado de MQTT")
+	p.mu.Lock()
+	client := p.client
+	p.connected = false
+	p.mu.Unlock()
+
+	if client != nil && client.IsConnected() {
+		client.Disconnect(250)
+		p.notifyConnection(false, nil)
+		p.logger.Info("desconectado de MQTT")
 	}
 }
@@ -0,0 +1,247 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"simulador-hard/adapters/faults"
+	"simulador-hard/adapters/hardware/room"
+	"simulador-hard/logging"
+	"simulador-hard/ports"
+)
+
+// MotionController es el PIR al que se le puede forzar o suprimir la detección
+type MotionController interface {
+	ForceMotion(d time.Duration)
+	SuppressMotion(d time.Duration)
+}
+
+// Targets son los componentes sobre los que actúa un escenario; los que estén
+// en nil hacen fallar las instrucciones que los necesitan
+type Targets struct {
+	Overlay    *Overlay
+	Room       *room.Room
+	Motion     MotionController
+	Devices    ports.DeviceController
+	Faults     *faults.Injector
+	Publishers map[string]ports.DataPublisher
+}
+
+// Action es una instrucción del escenario
+type Action interface {
+	apply(e *Engine, now time.Time) error
+}
+
+// Source agrega una fuente con rampa en una mesa
+type Source struct {
+	Species           room.Species
+	Mesa              int
+	Peak              float64
+	Over, Hold, Decay time.Duration
+}
+
+// Leak libera una sustancia en la sala en la posición de una mesa
+type Leak struct {
+	Species  room.Species
+	Mesa     int
+	Rate     float64
+	Duration time.Duration
+}
+
+// MotionBurst fuerza detección en el PIR USB
+type MotionBurst struct {
+	Duration time.Duration
+}
+
+// MotionQuiet suprime las detecciones aleatorias del PIR USB
+type MotionQuiet struct {
+	Duration time.Duration
+}
+
+// Disconnect desconecta un publicador; con Duration se reconecta después
+type Disconnect struct {
+	Publisher string
+	Duration  time.Duration
+}
+
+// Connect reconecta un publicador
+type Connect struct {
+	Publisher string
+}
+
+// DeviceAction detiene o arranca un dispositivo
+type DeviceAction struct {
+	Device string
+	Start  bool
+}
+
+// Fault registra una falla en el inyector; con duración se elimina al terminar
+type Fault struct {
+	Rule faults.Rule
+}
+
+// ClearFaults elimina todas las fallas
+type ClearFaults struct{}
+
+// Engine ejecuta un escenario en tiempo real
+type Engine struct {
+	scenario *Scenario
+	targets  Targets
+	logger   *slog.Logger
+	pending  sync.WaitGroup
+}
+
+// NewEngine crea el motor de un escenario
+func NewEngine(s *Scenario, targets Targets) *Engine {
+	return &Engine{
+		scenario: s,
+		targets:  targets,
+		logger:   logging.For("scenario"),
+	}
+}
+
+// Run ejecuta los eventos en orden hasta terminar o hasta que se cancele ctx.
+// Un evento que falla se registra y el escenario continúa.
+func (e *Engine) Run(ctx context.Context) {
+	start := time.Now()
+	e.logger.Info("escenario iniciado", "name", e.scenario.Name, "events", len(e.scenario.Events), "duration", e.scenario.Duration())
+
+	for _, ev := range e.scenario.Events {
+		if wait := time.Until(start.Add(ev.At)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				e.logger.Info("escenario cancelado", "name", e.scenario.Name)
+				return
+			case <-timer.C:
+			}
+		}
+
+		if err := ev.Action.apply(e, time.Now()); err != nil {
+			e.logger.Error("evento fallido", "line", ev.Line, "event", ev.Text, "error", err)
+			continue
+		}
+		e.logger.Info("evento ejecutado", "at", ev.At, "event", ev.Text)
+	}
+
+	e.pending.Wait()
+	e.logger.Info("escenario terminado", "name", e.scenario.Name)
+}
+
+// after ejecuta fn pasado d; Run espera a que terminen antes de retornar
+func (e *Engine) after(d time.Duration, fn func()) {
+	e.pending.Add(1)
+	time.AfterFunc(d, func() {
+		defer e.pending.Done()
+		fn()
+	})
+}
+
+func (e *Engine) publisher(name string) (ports.DataPublisher, error) {
+	p, ok := e.targets.Publishers[name]
+	if !ok {
+		return nil, fmt.Errorf("publicador desconocido %q", name)
+	}
+	return p, nil
+}
+
+func (a Source) apply(e *Engine, now time.Time) error {
+	if e.targets.Overlay == nil {
+		return fmt.Errorf("no hay ambiente de escenario")
+	}
+	e.targets.Overlay.AddSource(a.Mesa, a.Species, a.Peak, now, a.Over, a.Hold, a.Decay)
+	return nil
+}
+
+func (a Leak) apply(e *Engine, now time.Time) error {
+	if e.targets.Room == nil {
+		return fmt.Errorf("leak requiere el modelo de sala")
+	}
+	at, ok := e.targets.Room.Mesa(a.Mesa)
+	if !ok {
+		return fmt.Errorf("la sala no tiene la mesa %d", a.Mesa)
+	}
+	e.targets.Room.Emit(room.Release{Species: a.Species, At: at, Rate: a.Rate, Duration: a.Duration})
+	return nil
+}
+
+func (a MotionBurst) apply(e *Engine, now time.Time) error {
+	if e.targets.Motion == nil {
+		return fmt.Errorf("no hay PIR USB")
+	}
+	e.targets.Motion.ForceMotion(a.Duration)
+	return nil
+}
+
+func (a MotionQuiet) apply(e *Engine, now time.Time) error {
+	if e.targets.Motion == nil {
+		return fmt.Errorf("no hay PIR USB")
+	}
+	e.targets.Motion.SuppressMotion(a.Duration)
+	return nil
+}
+
+func (a Disconnect) apply(e *Engine, now time.Time) error {
+	p, err := e.publisher(a.Publisher)
+	if err != nil {
+		return err
+	}
+	p.Disconnect()
+	if a.Duration > 0 {
+		e.after(a.Duration, func() {
+			if err := p.Connect(); err != nil {
+				e.logger.Error("no se pudo reconectar", "publisher", a.Publisher, "error", err)
+				return
+			}
+			e.logger.Info("publicador reconectado", "publisher", a.Publisher)
+		})
+	}
+	return nil
+}
+
+func (a Connect) apply(e *Engine, now time.Time) error {
+	p, err := e.publisher(a.Publisher)
+	if err != nil {
+		return err
+	}
+	return p.Connect()
+}
+
+func (a DeviceAction) apply(e *Engine, now time.Time) error {
+	if e.targets.Devices == nil {
+		return fmt.Errorf("no hay controlador de dispositivos")
+	}
+	var err error
+	if a.Start {
+		_, err = e.targets.Devices.StartDevice(a.Device)
+	} else {
+		_, err = e.targets.Devices.StopDevice(a.Device)
+	}
+	return err
+}
+
+func (a Fault) apply(e *Engine, now time.Time) error {
+	if e.targets.Faults == nil {
+		return fmt.Errorf("no hay inyector de fallas")
+	}
+	id, err := e.targets.Faults.Add(a.Rule)
+	if err != nil {
+		return err
+	}
+	if d := time.Duration(a.Rule.Trigger.Duration); d > 0 {
+		e.after(d, func() { e.targets.Faults.Remove(id) })
+	}
+	return nil
+}
+
+func (a ClearFaults) apply(e *Engine, now time.Time) error {
+	if e.targets.Faults == nil {
+		return fmt.Errorf("no hay inyector de fallas")
+	}
+	e.targets.Faults.Clear()
+	return nil
+}
@@ -0,0 +1,106 @@
+package scenario
+
+import (
+	"sync"
+	"time"
+
+	"simulador-hard/adapters/hardware"
+	"simulador-hard/adapters/hardware/room"
+)
+
+// Overlay es el ambiente que ven los ESP32: el ambiente base (la sala, si hay)
+// más las fuentes con rampa que agrega el escenario en cada mesa
+type Overlay struct {
+	base    hardware.Environment
+	mu      sync.Mutex
+	sources []source
+}
+
+// source sube linealmente hasta peak en over, se mantiene hold y baja en decay
+type source struct {
+	mesa    int
+	species room.Species
+	peak    float64
+	start   time.Time
+	over    time.Duration
+	hold    time.Duration
+	decay   time.Duration
+}
+
+// NewOverlay crea el ambiente del escenario sobre base (puede ser nil)
+func NewOverlay(base hardware.Environment) *Overlay {
+	return &Overlay{base: base}
+}
+
+// AddSource agrega una fuente en la mesa que empieza en start
+func (o *Overlay) AddSource(mesa int, species room.Species, peak float64, start time.Time, over, hold, decay time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sources = append(o.sources, source{
+		mesa:    mesa,
+		species: species,
+		peak:    peak,
+		start:   start,
+		over:    over,
+		hold:    hold,
+		decay:   decay,
+	})
+}
+
+// Clear elimina todas las fuentes del escenario
+func (o *Overlay) Clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sources = nil
+}
+
+// Exposure implementa hardware.Environment
+func (o *Overlay) Exposure(mesaID int, t time.Time) hardware.Exposure {
+	var exp hardware.Exposure
+	if o.base != nil {
+		exp = o.base.Exposure(mesaID, t)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	kept := o.sources[:0]
+	for _, src := range o.sources {
+		v, done := src.value(t)
+		if !done {
+			kept = append(kept, src)
+		}
+		if src.mesa != mesaID || v == 0 {
+			continue
+		}
+		switch src.species {
+		case room.LPG:
+			exp.LPG += v
+		case room.CO:
+			exp.CO += v
+		case room.Smoke:
+			exp.Smoke += v
+		case room.PM:
+			exp = exp.Add(hardware.PMExposure(v))
+		}
+	}
+	o.sources = kept
+	return exp
+}
+
+// value retorna el aporte en t y si la fuente ya terminó
+func (s source) value(t time.Time) (float64, bool) {
+	elapsed := t.Sub(s.start)
+	switch {
+	case elapsed < 0:
+		return 0, false
+	case elapsed < s.over:
+		return s.peak * float64(elapsed) / float64(s.over), false
+	case elapsed < s.over+s.hold:
+		return s.peak, false
+	case elapsed < s.over+s.hold+s.decay:
+		left := s.over + s.hold + s.decay - elapsed
+		return s.peak * float64(left) / float64(s.decay), false
+	}
+	return 0, true
+}
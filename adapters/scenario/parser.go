@@ -0,0 +1,391 @@
+// Package scenario ejecuta incidentes guionados sobre los simuladores.
+//
+// Un escenario es un archivo de texto con una instrucción por línea; # inicia
+// un comentario. Los tiempos se cuentan desde el arranque del escenario y
+// aceptan mm:ss, hh:mm:ss o duraciones de Go (90s, 2m).
+//
+//	scenario <nombre>
+//	at 00:30 smoke source mesa 3 ramp 900 over 20s [hold 1m] [decay 20s]
+//	at 00:45 lpg leak mesa 2 rate 150 for 10s
+//	at 01:00 motion burst usb for 15s
+//	at 01:00 motion quiet usb for 10m
+//	at 02:00 disconnect mqtt for 30s
+//	at 02:10 connect coap
+//	at 03:00 fault mesa1/gas stuck field=co value=400 for 1m
+//	at 03:30 clear faults
+//	at 04:00 stop mesa2
+//	at 04:30 start mesa2
+//
+// source suma la concentración directamente en la mesa (ppm o µg/m³ de PM2.5
+// sobre la línea base), con rampa de subida, meseta (1m por defecto) y bajada
+// (igual a la subida por defecto). leak libera la sustancia en la sala y llega
+// a las demás mesas por difusión; requiere el modelo de sala. Las especies son
+// lpg, co, smoke y pm.
+package scenario
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"simulador-hard/adapters/faults"
+	"simulador-hard/adapters/hardware/room"
+)
+
+const defaultHold = time.Minute
+
+// Scenario es un guion ya interpretado, con los eventos ordenados por tiempo
+type Scenario struct {
+	Name   string
+	Events []Event
+}
+
+// Event es una instrucción programada
+type Event struct {
+	At     time.Duration
+	Line   int
+	Text   string
+	Action Action
+}
+
+// Duration retorna el instante del último evento
+func (s *Scenario) Duration() time.Duration {
+	if len(s.Events) == 0 {
+		return 0
+	}
+	return s.Events[len(s.Events)-1].At
+}
+
+// Load lee y valida un archivo de escenario
+func Load(path string) (*Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse interpreta un escenario
+func Parse(r io.Reader) (*Scenario, error) {
+	s := &Scenario{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		t := &tokens{fields: strings.Fields(text)}
+		switch t.next() {
+		case "scenario":
+			s.Name = strings.Join(t.rest(), " ")
+		case "at":
+			ev, err := parseEvent(t)
+			if err != nil {
+				return nil, fmt.Errorf("línea %d: %w", line, err)
+			}
+			ev.Line = line
+			ev.Text = text
+			s.Events = append(s.Events, ev)
+		default:
+			return nil, fmt.Errorf("línea %d: se esperaba \"at\" o \"scenario\"", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(s.Events, func(i, j int) bool { return s.Events[i].At < s.Events[j].At })
+	return s, nil
+}
+
+func parseEvent(t *tokens) (Event, error) {
+	at, err := parseTime(t.next())
+	if err != nil {
+		return Event{}, err
+	}
+	action, err := parseAction(t)
+	if err != nil {
+		return Event{}, err
+	}
+	if extra := t.rest(); len(extra) > 0 {
+		return Event{}, fmt.Errorf("texto sobrante %q", strings.Join(extra, " "))
+	}
+	return Event{At: at, Action: action}, nil
+}
+
+func parseAction(t *tokens) (Action, error) {
+	word := t.next()
+	switch word {
+	case "motion":
+		mode := t.next()
+		if mode != "burst" && mode != "quiet" {
+			return nil, fmt.Errorf("se esperaba burst o quiet después de motion")
+		}
+		if err := t.expect("usb", "for"); err != nil {
+			return nil, err
+		}
+		d, err := t.duration()
+		if mode == "quiet" {
+			return MotionQuiet{Duration: d}, err
+		}
+		return MotionBurst{Duration: d}, err
+
+	case "disconnect":
+		a := Disconnect{Publisher: t.next()}
+		if a.Publisher == "" {
+			return nil, fmt.Errorf("falta el publicador a desconectar")
+		}
+		if t.peek() == "for" {
+			t.next()
+			d, err := t.duration()
+			a.Duration = d
+			return a, err
+		}
+		return a, nil
+
+	case "connect":
+		a := Connect{Publisher: t.next()}
+		if a.Publisher == "" {
+			return nil, fmt.Errorf("falta el publicador a conectar")
+		}
+		return a, nil
+
+	case "start", "stop":
+		device := t.next()
+		if device == "" {
+			return nil, fmt.Errorf("falta el dispositivo")
+		}
+		return DeviceAction{Device: device, Start: word == "start"}, nil
+
+	case "fault":
+		return parseFault(t)
+
+	case "clear":
+		if err := t.expect("faults"); err != nil {
+			return nil, err
+		}
+		return ClearFaults{}, nil
+	}
+
+	species, err := room.ParseSpecies(word)
+	if err != nil {
+		return nil, fmt.Errorf("acción desconocida %q", word)
+	}
+	switch t.next() {
+	case "source":
+		return parseSource(t, species)
+	case "leak":
+		return parseLeak(t, species)
+	}
+	return nil, fmt.Errorf("se esperaba source o leak después de %s", word)
+}
+
+func parseSource(t *tokens, species room.Species) (Action, error) {
+	a := Source{Species: species, Hold: defaultHold}
+	var err error
+	if err = t.expect("mesa"); err != nil {
+		return nil, err
+	}
+	if a.Mesa, err = t.int(); err != nil {
+		return nil, err
+	}
+	if err = t.expect("ramp"); err != nil {
+		return nil, err
+	}
+	if a.Peak, err = t.float(); err != nil {
+		return nil, err
+	}
+	if err = t.expect("over"); err != nil {
+		return nil, err
+	}
+	if a.Over, err = t.duration(); err != nil {
+		return nil, err
+	}
+	a.Decay = a.Over
+
+	for t.peek() == "hold" || t.peek() == "decay" {
+		switch t.next() {
+		case "hold":
+			a.Hold, err = t.duration()
+		case "decay":
+			a.Decay, err = t.duration()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func parseLeak(t *tokens, species room.Species) (Action, error) {
+	a := Leak{Species: species}
+	var err error
+	if err = t.expect("mesa"); err != nil {
+		return nil, err
+	}
+	if a.Mesa, err = t.int(); err != nil {
+		return nil, err
+	}
+	if err = t.expect("rate"); err != nil {
+		return nil, err
+	}
+	if a.Rate, err = t.float(); err != nil {
+		return nil, err
+	}
+	if err = t.expect("for"); err != nil {
+		return nil, err
+	}
+	a.Duration, err = t.duration()
+	return a, err
+}
+
+func parseFault(t *tokens) (Action, error) {
+	rule := faults.Rule{Sensor: t.next(), Fault: faults.Fault{Kind: faults.Kind(t.next())}}
+
+	for t.peek() != "" && t.peek() != "for" {
+		key, value, ok := strings.Cut(t.next(), "=")
+		if !ok {
+			return nil, fmt.Errorf("parámetro de falla inválido %q, se espera clave=valor", key)
+		}
+		if key == "field" {
+			rule.Fault.Field = value
+			continue
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		switch key {
+		case "value":
+			rule.Fault.Value = &v
+		case "rate":
+			rule.Fault.Rate = v
+		case "stddev":
+			rule.Fault.StdDev = v
+		case "probability":
+			rule.Fault.Probability = v
+		default:
+			return nil, fmt.Errorf("parámetro de falla desconocido %q", key)
+		}
+	}
+	if t.peek() == "for" {
+		t.next()
+		d, err := t.duration()
+		if err != nil {
+			return nil, err
+		}
+		rule.Trigger.Duration = faults.Duration(d)
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return Fault{Rule: rule}, nil
+}
+
+// parseTime acepta mm:ss, hh:mm:ss o una duración de Go
+func parseTime(s string) (time.Duration, error) {
+	if !strings.Contains(s, ":") {
+		return parseDuration(s)
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("tiempo inválido %q", s)
+	}
+	var total time.Duration
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("tiempo inválido %q", s)
+		}
+		total = total*60 + time.Duration(n)
+	}
+	return total * time.Second, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("duración inválida %q", s)
+	}
+	return d, nil
+}
+
+// tokens recorre las palabras de una línea
+type tokens struct {
+	fields []string
+	pos    int
+}
+
+func (t *tokens) next() string {
+	if t.pos >= len(t.fields) {
+		return ""
+	}
+	t.pos++
+	return strings.ToLower(t.fields[t.pos-1])
+}
+
+func (t *tokens) peek() string {
+	if t.pos >= len(t.fields) {
+		return ""
+	}
+	return strings.ToLower(t.fields[t.pos])
+}
+
+func (t *tokens) rest() []string {
+	rest := t.fields[t.pos:]
+	t.pos = len(t.fields)
+	return rest
+}
+
+func (t *tokens) expect(words ...string) error {
+	for _, w := range words {
+		if got := t.next(); got != w {
+			if got == "" {
+				return fmt.Errorf("se esperaba %q al final de la línea", w)
+			}
+			return fmt.Errorf("se esperaba %q y se encontró %q", w, got)
+		}
+	}
+	return nil
+}
+
+func (t *tokens) duration() (time.Duration, error) {
+	return parseDuration(t.next())
+}
+
+func (t *tokens) int() (int, error) {
+	s := t.next()
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("se esperaba un número entero y se encontró %q", s)
+	}
+	return n, nil
+}
+
+func (t *tokens) float() (float64, error) {
+	s := t.next()
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("se esperaba un número y se encontró %q", s)
+	}
+	return v, nil
+}
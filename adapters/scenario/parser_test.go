@@ -0,0 +1,218 @@
+package scenario
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"simulador-hard/adapters/faults"
+	"simulador-hard/adapters/hardware/room"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "00:30", want: 30 * time.Second},
+		{in: "02:05", want: 2*time.Minute + 5*time.Second},
+		{in: "1:00:00", want: time.Hour},
+		{in: "90s", want: 90 * time.Second},
+		{in: "2m30s", want: 2*time.Minute + 30*time.Second},
+		{in: "0", want: 0},
+		{in: "1:2:3:4", wantErr: true},
+		{in: "aa:10", wantErr: true},
+		{in: "-1:00", wantErr: true},
+		{in: "-5s", wantErr: true},
+		{in: "diez", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTime(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseTime(%q) = %v, se esperaba error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTime(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseTime(%q) = %v, se esperaba %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseActions(t *testing.T) {
+	value := 400.0
+	tests := []struct {
+		line string
+		want Action
+	}{
+		{
+			line: "at 00:30 smoke source mesa 3 ramp 900 over 20s",
+			want: Source{Species: room.Smoke, Mesa: 3, Peak: 900, Over: 20 * time.Second, Hold: defaultHold, Decay: 20 * time.Second},
+		},
+		{
+			line: "at 00:30 co source mesa 1 ramp 50.5 over 10s hold 2m decay 1m",
+			want: Source{Species: room.CO, Mesa: 1, Peak: 50.5, Over: 10 * time.Second, Hold: 2 * time.Minute, Decay: time.Minute},
+		},
+		{
+			line: "at 00:30 pm source mesa 2 ramp 80 over 5s decay 30s hold 0s",
+			want: Source{Species: room.PM, Mesa: 2, Peak: 80, Over: 5 * time.Second, Hold: 0, Decay: 30 * time.Second},
+		},
+		{
+			line: "at 00:45 lpg leak mesa 2 rate 150 for 10s",
+			want: Leak{Species: room.LPG, Mesa: 2, Rate: 150, Duration: 10 * time.Second},
+		},
+		{
+			line: "at 01:00 motion burst usb for 15s",
+			want: MotionBurst{Duration: 15 * time.Second},
+		},
+		{
+			line: "at 01:00 motion quiet usb for 10m",
+			want: MotionQuiet{Duration: 10 * time.Minute},
+		},
+		{
+			line: "at 02:00 disconnect mqtt for 30s",
+			want: Disconnect{Publisher: "mqtt", Duration: 30 * time.Second},
+		},
+		{
+			line: "at 02:00 disconnect coap",
+			want: Disconnect{Publisher: "coap"},
+		},
+		{
+			line: "at 02:10 connect broker",
+			want: Connect{Publisher: "broker"},
+		},
+		{
+			line: "at 04:00 stop mesa2",
+			want: DeviceAction{Device: "mesa2", Start: false},
+		},
+		{
+			line: "at 04:30 START USB",
+			want: DeviceAction{Device: "usb", Start: true},
+		},
+		{
+			line: "at 03:00 fault mesa1/gas stuck field=co value=400 for 1m",
+			want: Fault{Rule: faults.Rule{
+				Sensor:  "mesa1/gas",
+				Fault:   faults.Fault{Kind: faults.KindStuck, Field: "co", Value: &value},
+				Trigger: faults.Trigger{Duration: faults.Duration(time.Minute)},
+			}},
+		},
+		{
+			line: "at 03:00 fault +/particles noise stddev=5",
+			want: Fault{Rule: faults.Rule{
+				Sensor: "+/particles",
+				Fault:  faults.Fault{Kind: faults.KindNoise, StdDev: 5},
+			}},
+		},
+		{
+			line: "at 03:00 fault mesa2/gas drift rate=0.5 probability=0.2",
+			want: Fault{Rule: faults.Rule{
+				Sensor: "mesa2/gas",
+				Fault:  faults.Fault{Kind: faults.KindDrift, Rate: 0.5, Probability: 0.2},
+			}},
+		},
+		{
+			line: "at 03:30 clear faults",
+			want: ClearFaults{},
+		},
+	}
+	for _, tt := range tests {
+		s, err := Parse(strings.NewReader(tt.line))
+		if err != nil {
+			t.Errorf("%q: %v", tt.line, err)
+			continue
+		}
+		if len(s.Events) != 1 {
+			t.Errorf("%q: %d eventos, se esperaba 1", tt.line, len(s.Events))
+			continue
+		}
+		if got := s.Events[0].Action; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q:\n got  %#v\n want %#v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"sin at", "smoke source mesa 1 ramp 10 over 5s"},
+		{"tiempo inválido", "at ayer motion burst usb for 5s"},
+		{"acción desconocida", "at 00:10 explode mesa 1"},
+		{"texto sobrante", "at 00:10 connect mqtt ya"},
+		{"texto sobrante en source", "at 00:10 smoke source mesa 1 ramp 10 over 5s lento"},
+		{"falta for en leak", "at 00:10 lpg leak mesa 1 rate 5 10s"},
+		{"falta for en motion", "at 00:10 motion burst usb 10s"},
+		{"motion sin usb", "at 00:10 motion burst for 10s"},
+		{"modo de motion", "at 00:10 motion storm usb for 10s"},
+		{"source sin over", "at 00:10 co source mesa 1 ramp 10"},
+		{"mesa no numérica", "at 00:10 co source mesa uno ramp 10 over 5s"},
+		{"pico no numérico", "at 00:10 co source mesa 1 ramp mucho over 5s"},
+		{"duración inválida", "at 00:10 co source mesa 1 ramp 10 over rapido"},
+		{"especie sin source ni leak", "at 00:10 co spill mesa 1"},
+		{"disconnect sin publicador", "at 00:10 disconnect"},
+		{"connect sin publicador", "at 00:10 connect"},
+		{"stop sin dispositivo", "at 00:10 stop"},
+		{"clear sin faults", "at 00:10 clear all"},
+		{"fault sin clave=valor", "at 00:10 fault mesa1/gas stuck 400"},
+		{"fault con clave desconocida", "at 00:10 fault mesa1/gas stuck level=400"},
+		{"fault con valor no numérico", "at 00:10 fault mesa1/gas stuck value=alto"},
+		{"fault de tipo desconocido", "at 00:10 fault mesa1/gas melt"},
+		{"fault sin sensor", "at 00:10 fault"},
+		{"fault con for inválido", "at 00:10 fault mesa1/gas dead for siempre"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(strings.NewReader(tt.line)); err == nil {
+			t.Errorf("%s: %q se aceptó", tt.name, tt.line)
+		}
+	}
+}
+
+func TestParseScenario(t *testing.T) {
+	src := `
+# Comentario completo
+scenario prueba de humo
+at 01:00 clear faults
+at 00:10 motion burst usb for 5s  # comentario al final
+
+at 00:30 connect mqtt
+`
+	s, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "prueba de humo" {
+		t.Errorf("Name = %q", s.Name)
+	}
+	var at []time.Duration
+	var lines []int
+	for _, ev := range s.Events {
+		at = append(at, ev.At)
+		lines = append(lines, ev.Line)
+	}
+	if want := []time.Duration{10 * time.Second, 30 * time.Second, time.Minute}; !reflect.DeepEqual(at, want) {
+		t.Errorf("eventos en %v, se esperaba %v", at, want)
+	}
+	if want := []int{5, 7, 4}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("líneas %v, se esperaba %v", lines, want)
+	}
+	if s.Duration() != time.Minute {
+		t.Errorf("Duration = %v", s.Duration())
+	}
+}
+
+func TestParseErrorLine(t *testing.T) {
+	_, err := Parse(strings.NewReader("scenario x\nat 00:10 clear faults\nat 00:20 connect\n"))
+	if err == nil || !strings.Contains(err.Error(), "línea 3") {
+		t.Errorf("error = %v, se esperaba la línea 3", err)
+	}
+}
@@ -0,0 +1,89 @@
+package scenario
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Los guiones de presets/ documentan en su encabezado las alertas que debe
+// generar el backend, para usarlos como fixtures de aceptación
+//
+//go:embed presets/*.scn
+var presetFiles embed.FS
+
+// Preset es un escenario predefinido que coordina gas, partículas, PIR y cámara
+type Preset struct {
+	Name        string
+	File        string
+	Description string
+	// UsesMesa indica que el guion se parametriza con la mesa del incidente
+	UsesMesa bool
+}
+
+// Presets lista los escenarios incluidos
+var Presets = []Preset{
+	{Name: "normal-lab-day", File: "normal-lab-day.scn", Description: "día normal de laboratorio, sin alertas"},
+	{Name: "fire", File: "fire.scn", Description: "incendio en una mesa: humo, CO y PM2.5", UsesMesa: true},
+	{Name: "gas-leak", File: "gas-leak.scn", Description: "fuga de LPG en una mesa que se difunde por la sala", UsesMesa: true},
+	{Name: "intrusion-night", File: "intrusion-night.scn", Description: "laboratorio vacío con tres pasadas frente al PIR"},
+	{Name: "sensor-degradation", File: "sensor-degradation.scn", Description: "ruido, pérdidas, deriva y un ESP32 caído"},
+	{Name: "broker-outage", File: "broker-outage.scn", Description: "broker MQTT caído dos minutos durante un pico de humo", UsesMesa: true},
+}
+
+// LoadPreset interpreta un preset por nombre. El nombre acepta la mesa como
+// sufijo ("fire:3"); sin sufijo se usa la mesa 1.
+func LoadPreset(name string) (*Scenario, error) {
+	name, mesaText, hasMesa := strings.Cut(name, ":")
+	mesa := 1
+	if hasMesa {
+		n, err := strconv.Atoi(mesaText)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("scenario: mesa inválida %q", mesaText)
+		}
+		mesa = n
+	}
+
+	for _, p := range Presets {
+		if p.Name != name {
+			continue
+		}
+		if hasMesa && !p.UsesMesa {
+			return nil, fmt.Errorf("scenario: el preset %q no usa mesa", name)
+		}
+		return p.load(mesa)
+	}
+	return nil, fmt.Errorf("scenario: preset desconocido %q", name)
+}
+
+// Script retorna el guion del preset para una mesa, con su documentación
+func (p Preset) Script(mesa int) (string, error) {
+	data, err := presetFiles.ReadFile("presets/" + p.File)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(p.Name).Parse(string(data))
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Mesa int }{mesa}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (p Preset) load(mesa int) (*Scenario, error) {
+	script, err := p.Script(mesa)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(strings.NewReader(script))
+	if err != nil {
+		return nil, fmt.Errorf("preset %s: %w", p.Name, err)
+	}
+	return s, nil
+}
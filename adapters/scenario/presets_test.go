@@ -0,0 +1,75 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPresetsParse(t *testing.T) {
+	for _, p := range Presets {
+		names := []string{p.Name}
+		if p.UsesMesa {
+			for _, mesa := range []int{1, 2, 4, 12} {
+				names = append(names, fmt.Sprintf("%s:%d", p.Name, mesa))
+			}
+		}
+		for _, name := range names {
+			s, err := LoadPreset(name)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if s.Name == "" {
+				t.Errorf("%s: sin nombre de escenario", name)
+			}
+			if len(s.Events) == 0 {
+				t.Errorf("%s: sin eventos", name)
+			}
+			for _, ev := range s.Events {
+				if strings.Contains(ev.Text, "{{") {
+					t.Errorf("%s línea %d: plantilla sin reemplazar: %s", name, ev.Line, ev.Text)
+				}
+			}
+		}
+	}
+}
+
+func TestPresetUsesMesa(t *testing.T) {
+	for _, p := range Presets {
+		if !p.UsesMesa {
+			continue
+		}
+		s, err := LoadPreset(p.Name + ":3")
+		if err != nil {
+			t.Fatalf("%s: %v", p.Name, err)
+		}
+		sources := 0
+		for _, ev := range s.Events {
+			mesa := 0
+			switch a := ev.Action.(type) {
+			case Source:
+				mesa = a.Mesa
+			case Leak:
+				mesa = a.Mesa
+			default:
+				continue
+			}
+			sources++
+			if mesa != 3 {
+				t.Errorf("%s:3 línea %d: mesa %d", p.Name, ev.Line, mesa)
+			}
+		}
+		if sources == 0 {
+			t.Errorf("%s:3: ninguna fuente usa la mesa", p.Name)
+		}
+	}
+}
+
+func TestLoadPresetErrors(t *testing.T) {
+	for _, name := range []string{"desconocido", "fire:0", "fire:x", "normal-lab-day:2"} {
+		if _, err := LoadPreset(name); err == nil {
+			t.Errorf("LoadPreset(%q) se aceptó", name)
+		}
+	}
+}
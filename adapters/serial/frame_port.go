@@ -0,0 +1,125 @@
+package serial
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+
+	"simulador-hard/logging"
+)
+
+// FramePort emula un UART binario, como el del PMS5003, sobre un pseudo-terminal.
+// Solo escribe los payloads []byte tal cual; lo que envía el cliente se descarta.
+type FramePort struct {
+	linkPath string
+	mu       sync.RWMutex
+	master   *os.File
+	slave    *os.File
+	frames   chan []byte
+	open     bool
+	logger   *slog.Logger
+}
+
+// NewFramePort crea un puerto binario con enlace opcional en linkPath
+func NewFramePort(linkPath string) *FramePort {
+	return &FramePort{
+		linkPath: linkPath,
+		logger:   logging.For("serial").With("link", linkPath),
+	}
+}
+
+// Connect abre el pty y arranca la escritura
+func (p *FramePort) Connect() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.open {
+		return nil
+	}
+
+	master, slave, err := openPTY()
+	if err != nil {
+		return err
+	}
+	if err := createLink(slave.Name(), p.linkPath); err != nil {
+		slave.Close()
+		master.Close()
+		return err
+	}
+
+	p.master = master
+	p.slave = slave
+	p.frames = make(chan []byte, writeBuffer)
+	p.open = true
+
+	go p.writeLoop(master, p.frames)
+	go discard(master)
+
+	p.logger.Info("puerto binario disponible", "pty", slave.Name())
+	return nil
+}
+
+// Publish escribe el payload si es una trama binaria
+func (p *FramePort) Publish(topic string, payload interface{}) error {
+	frame, ok := payload.([]byte)
+	if !ok {
+		return nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.open {
+		return nil
+	}
+	select {
+	case p.frames <- frame:
+	default:
+		p.logger.Debug("trama descartada, buffer lleno")
+	}
+	return nil
+}
+
+// IsConnected indica si el pty está abierto
+func (p *FramePort) IsConnected() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.open
+}
+
+// Disconnect cierra el pty y elimina el enlace simbólico
+func (p *FramePort) Disconnect() {
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return
+	}
+	p.open = false
+	close(p.frames)
+	p.mu.Unlock()
+
+	p.master.Close()
+	p.slave.Close()
+	if p.linkPath != "" {
+		os.Remove(p.linkPath)
+	}
+	p.logger.Info("puerto binario cerrado")
+}
+
+func (p *FramePort) writeLoop(master *os.File, frames <-chan []byte) {
+	for frame := range frames {
+		if _, err := master.Write(frame); err != nil {
+			p.logger.Debug("error escribiendo en el pty", "error", err)
+		}
+	}
+}
+
+// discard vacía la entrada para que el cliente no se bloquee al escribir
+func discard(master *os.File) {
+	buf := make([]byte, 256)
+	for {
+		if _, err := master.Read(buf); err != nil {
+			return
+		}
+	}
+}
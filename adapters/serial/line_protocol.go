@@ -0,0 +1,57 @@
+package serial
+
+import (
+	"fmt"
+
+	"simulador-hard/domain"
+)
+
+// Protocolo de línea del firmware ESP32 (115200 8N1, líneas terminadas en \r\n):
+//
+//	GAS,<mesa>,<lpg>,<co>,<smoke>
+//	PM,<mesa>,<pm1_0>,<pm2_5>,<pm10>
+//	DHT,<mesa>,<temperatura>,<humedad>
+//	FLAME,<mesa>,<0|1>,<nivel_ir>
+//	SND,<mesa>,<db>
+//	CO2,<mesa>,<ppm>
+//
+// Comandos aceptados (sin distinguir mayúsculas):
+//
+//	PING            -> PONG
+//	INFO            -> INFO,<sensor_base>,<firmware>
+//	STATUS          -> STATUS,<running|stopped>,<streaming|paused>
+//	READ            -> última línea de cada sensor montado
+//	PAUSE / RESUME  -> detiene o reanuda el envío periódico
+//	START / STOP    -> arranca o detiene los sensores del ESP32
+//
+// Las respuestas a comandos sin datos son OK o ERR,<motivo>.
+const (
+	lineEnding      = "\r\n"
+	firmwareVersion = "vigiltech-fw-1.4.2"
+)
+
+// sensorKinds es el orden de las líneas de READ
+var sensorKinds = []string{"mq135", "pms5003", "dht22", "flame", "sound", "mhz19"}
+
+// FormatLine convierte una lectura en su línea del protocolo serie
+func FormatLine(payload interface{}) (string, bool) {
+	switch r := payload.(type) {
+	case domain.GasReading:
+		return fmt.Sprintf("GAS,%d,%.2f,%.2f,%.2f", r.SystemID, r.LPG, r.CO, r.Smoke), true
+	case domain.ParticleReading:
+		return fmt.Sprintf("PM,%d,%.1f,%.1f,%.1f", r.SystemID, r.PM10, r.PM25, r.PM100), true
+	case domain.ClimateReading:
+		return fmt.Sprintf("DHT,%d,%.1f,%.1f", r.SystemID, r.Temperature, r.Humidity), true
+	case domain.FlameReading:
+		flame := 0
+		if r.FlameDetected {
+			flame = 1
+		}
+		return fmt.Sprintf("FLAME,%d,%d,%d", r.SystemID, flame, r.IRLevel), true
+	case domain.SoundReading:
+		return fmt.Sprintf("SND,%d,%.1f", r.SystemID, r.LevelDB), true
+	case domain.CO2Reading:
+		return fmt.Sprintf("CO2,%d,%.0f", r.SystemID, r.CO2), true
+	}
+	return "", false
+}
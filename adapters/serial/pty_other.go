@@ -0,0 +1,12 @@
+//go:build !linux
+
+package serial
+
+import (
+	"errors"
+	"os"
+)
+
+func openPTY() (master, slave *os.File, err error) {
+	return nil, nil, errors.New("serial: los pseudo-terminales solo están soportados en Linux")
+}
@@ -0,0 +1,244 @@
+package serial
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"simulador-hard/logging"
+	"simulador-hard/ports"
+)
+
+const writeBuffer = 64
+
+// SerialPort emula el puerto USB-serie de un ESP32 sobre un pseudo-terminal.
+// Implementa ports.DataPublisher para recibir las lecturas del simulador.
+type SerialPort struct {
+	mesaID   int
+	linkPath string
+	mu       sync.RWMutex
+	master   *os.File
+	slave    *os.File
+	device   ports.ESP32Simulator
+	lines    chan string
+	done     chan struct{}
+	paused   bool
+	open     bool
+	logger   *slog.Logger
+}
+
+// NewSerialPort crea el puerto de una mesa. Si linkPath no está vacío se crea
+// un enlace simbólico estable (por ejemplo /tmp/ttyESP32-1) al esclavo del pty.
+func NewSerialPort(mesaID int, linkPath string) *SerialPort {
+	return &SerialPort{
+		mesaID:   mesaID,
+		linkPath: linkPath,
+		logger:   logging.For("serial").With("mesa", mesaID),
+	}
+}
+
+// SetDevice asigna el simulador que atienden los comandos START/STOP/READ
+func (p *SerialPort) SetDevice(device ports.ESP32Simulator) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.device = device
+}
+
+// Connect abre el pty y arranca las goroutines de lectura y escritura
+func (p *SerialPort) Connect() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.open {
+		return nil
+	}
+
+	master, slave, err := openPTY()
+	if err != nil {
+		return err
+	}
+
+	if err := createLink(slave.Name(), p.linkPath); err != nil {
+		slave.Close()
+		master.Close()
+		return err
+	}
+
+	p.master = master
+	p.slave = slave
+	p.lines = make(chan string, writeBuffer)
+	p.done = make(chan struct{})
+	p.open = true
+
+	go p.writeLoop(master, p.lines)
+	go p.readLoop(master, p.done)
+
+	p.logger.Info("puerto serie disponible", "pty", slave.Name(), "link", p.linkPath)
+	return nil
+}
+
+// Path retorna el dispositivo que debe abrir el gateway
+func (p *SerialPort) Path() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.linkPath != "" {
+		return p.linkPath
+	}
+	if p.slave != nil {
+		return p.slave.Name()
+	}
+	return ""
+}
+
+// Publish escribe la lectura en el puerto salvo que el envío esté pausado
+func (p *SerialPort) Publish(topic string, payload interface{}) error {
+	line, ok := FormatLine(payload)
+	if !ok {
+		return nil
+	}
+
+	p.mu.RLock()
+	paused := p.paused
+	p.mu.RUnlock()
+
+	if paused {
+		return nil
+	}
+	p.writeLine(line)
+	return nil
+}
+
+// IsConnected indica si el pty está abierto
+func (p *SerialPort) IsConnected() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.open
+}
+
+// Disconnect cierra el pty y elimina el enlace simbólico
+func (p *SerialPort) Disconnect() {
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return
+	}
+	p.open = false
+	close(p.lines)
+	p.mu.Unlock()
+
+	p.master.Close()
+	p.slave.Close()
+	<-p.done
+	if p.linkPath != "" {
+		os.Remove(p.linkPath)
+	}
+	p.logger.Info("puerto serie cerrado")
+}
+
+// writeLine encola una línea sin bloquear al simulador; si el gateway no lee se descarta
+func (p *SerialPort) writeLine(line string) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.open {
+		return
+	}
+	select {
+	case p.lines <- line + lineEnding:
+	default:
+		p.logger.Debug("línea serie descartada, buffer lleno")
+	}
+}
+
+func (p *SerialPort) writeLoop(master *os.File, lines <-chan string) {
+	for line := range lines {
+		if _, err := master.WriteString(line); err != nil {
+			p.logger.Debug("error escribiendo en el pty", "error", err)
+		}
+	}
+}
+
+func (p *SerialPort) readLoop(master *os.File, done chan<- struct{}) {
+	defer close(done)
+
+	scanner := bufio.NewScanner(master)
+	for scanner.Scan() {
+		cmd := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+		if cmd == "" {
+			continue
+		}
+		for _, reply := range p.handleCommand(cmd) {
+			p.writeLine(reply)
+		}
+	}
+}
+
+func (p *SerialPort) handleCommand(cmd string) []string {
+	p.logger.Debug("comando serie", "command", cmd)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch cmd {
+	case "PING":
+		return []string{"PONG"}
+	case "INFO":
+		return []string{fmt.Sprintf("INFO,ESP32-MESA-%d,%s", p.mesaID, firmwareVersion)}
+	case "PAUSE":
+		p.paused = true
+		return []string{"OK"}
+	case "RESUME":
+		p.paused = false
+		return []string{"OK"}
+	}
+
+	if p.device == nil {
+		return []string{"ERR,NO_DEVICE"}
+	}
+
+	switch cmd {
+	case "STATUS":
+		running, streaming := "stopped", "streaming"
+		if p.device.IsRunning() {
+			running = "running"
+		}
+		if p.paused {
+			streaming = "paused"
+		}
+		return []string{fmt.Sprintf("STATUS,%s,%s", running, streaming)}
+	case "READ":
+		var replies []string
+		for _, kind := range sensorKinds {
+			reading, ok := p.device.GetSensorReading(kind)
+			if !ok {
+				continue
+			}
+			if line, ok := FormatLine(reading); ok {
+				replies = append(replies, line)
+			}
+		}
+		return replies
+	case "START":
+		p.device.Start()
+		return []string{"OK"}
+	case "STOP":
+		p.device.Stop()
+		return []string{"OK"}
+	}
+	return []string{"ERR,UNKNOWN_COMMAND"}
+}
+
+// createLink reemplaza linkPath por un enlace simbólico al esclavo del pty
+func createLink(target, linkPath string) error {
+	if linkPath == "" {
+		return nil
+	}
+	os.Remove(linkPath)
+	if err := os.Symlink(target, linkPath); err != nil {
+		return fmt.Errorf("serial: enlace %s: %w", linkPath, err)
+	}
+	return nil
+}
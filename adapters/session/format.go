@@ -0,0 +1,38 @@
+package session
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Formato de sesión: JSONL con una cabecera y una línea por mensaje publicado.
+//
+//	{"version":1,"started_at":"2026-10-18T10:00:00Z"}
+//	{"offset_ms":1800.4,"topic":"vigiltech/sensors/mesa1/gas","table":"gas_sensor","payload":{...}}
+//
+// offset_ms es el tiempo desde el inicio de la grabación. table identifica el
+// tipo de lectura para reconstruirla al reproducir; si está vacío el payload se
+// reenvía como JSON crudo. domain.PMS5003Reading no tiene tabla en el backend y
+// se graba con table "pms5003". Los payloads binarios (tramas PMS5003 crudas) se
+// guardan en base64 con "binary":true y se reproducen como []byte.
+const formatVersion = 1
+
+// tablePMS5003 identifica las lecturas extendidas del PMS5003 en la sesión
+const tablePMS5003 = "pms5003"
+
+type header struct {
+	Version   int       `json:"version"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+type entry struct {
+	OffsetMs float64         `json:"offset_ms"`
+	Topic    string          `json:"topic"`
+	Table    string          `json:"table,omitempty"`
+	Binary   bool            `json:"binary,omitempty"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+func (e entry) offset() time.Duration {
+	return time.Duration(e.OffsetMs * float64(time.Millisecond))
+}
@@ -0,0 +1,183 @@
+package session
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+	"simulador-hard/ports"
+)
+
+const maxLineSize = 1 << 20
+
+// PlayerConfig define la velocidad de reproducción.
+// Speed 1 respeta los tiempos originales, 2 reproduce al doble y 0 (o negativo)
+// publica todo sin esperas. RewriteTimestamps reemplaza el timestamp de cada
+// lectura por el instante en que se vuelve a publicar.
+type PlayerConfig struct {
+	Speed             float64
+	RewriteTimestamps bool
+}
+
+// Player reproduce una sesión grabada a través de cualquier ports.DataPublisher
+type Player struct {
+	path   string
+	cfg    PlayerConfig
+	logger *slog.Logger
+}
+
+// NewPlayer crea un reproductor de sesión
+func NewPlayer(path string, cfg PlayerConfig) *Player {
+	return &Player{
+		path:   path,
+		cfg:    cfg,
+		logger: logging.For("session"),
+	}
+}
+
+// Play publica los mensajes en orden hasta el final del archivo o hasta que se cancele ctx.
+// Retorna la cantidad de mensajes publicados.
+func (p *Player) Play(ctx context.Context, publisher ports.DataPublisher) (int, error) {
+	file, err := os.Open(p.path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	if !scanner.Scan() {
+		return 0, fmt.Errorf("session: %s vacío", p.path)
+	}
+	var h header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil || h.Version != formatVersion {
+		return 0, fmt.Errorf("session: cabecera inválida en %s", p.path)
+	}
+
+	p.logger.Info("reproduciendo sesión", "path", p.path, "recorded_at", h.StartedAt, "speed", p.cfg.Speed)
+
+	start := time.Now()
+	published := 0
+	line := 1
+	for scanner.Scan() {
+		line++
+
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return published, fmt.Errorf("session: línea %d: %w", line, err)
+		}
+
+		if err := p.wait(ctx, start, e.offset()); err != nil {
+			return published, err
+		}
+
+		payload, err := p.payload(e)
+		if err != nil {
+			return published, fmt.Errorf("session: línea %d: %w", line, err)
+		}
+
+		if err := publisher.Publish(e.Topic, payload); err != nil {
+			p.logger.Warn("error reproduciendo mensaje", "topic", e.Topic, "line", line, "error", err)
+		}
+		published++
+	}
+	if err := scanner.Err(); err != nil {
+		return published, err
+	}
+
+	p.logger.Info("sesión reproducida", "path", p.path, "messages", published, "elapsed", time.Since(start).Round(time.Millisecond))
+	return published, nil
+}
+
+// wait duerme hasta el desplazamiento del mensaje escalado por la velocidad
+func (p *Player) wait(ctx context.Context, start time.Time, offset time.Duration) error {
+	if p.cfg.Speed <= 0 {
+		return ctx.Err()
+	}
+
+	delay := time.Until(start.Add(time.Duration(float64(offset) / p.cfg.Speed)))
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// payload reconstruye la lectura tipada para que los adaptadores la reconozcan
+func (p *Player) payload(e entry) (interface{}, error) {
+	if e.Binary {
+		var frame []byte
+		err := json.Unmarshal(e.Payload, &frame)
+		return frame, err
+	}
+	if e.Table == "" {
+		return e.Payload, nil
+	}
+
+	var reading interface{}
+	var err error
+	if e.Table == tablePMS5003 {
+		var r domain.PMS5003Reading
+		err = json.Unmarshal(e.Payload, &r)
+		reading = r
+	} else {
+		reading, err = domain.DecodeReading(e.Table, e.Payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.cfg.RewriteTimestamps {
+		reading = withTimestamp(reading, time.Now())
+	}
+	return reading, nil
+}
+
+func withTimestamp(reading interface{}, t time.Time) interface{} {
+	switch r := reading.(type) {
+	case domain.GasReading:
+		r.Timestamp = t
+		return r
+	case domain.ParticleReading:
+		r.Timestamp = t
+		return r
+	case domain.MotionReading:
+		r.Timestamp = t
+		return r
+	case domain.CameraReading:
+		r.Timestamp = t
+		return r
+	case domain.CameraStreamReading:
+		r.Timestamp = t
+		return r
+	case domain.ClimateReading:
+		r.Timestamp = t
+		return r
+	case domain.FlameReading:
+		r.Timestamp = t
+		return r
+	case domain.SoundReading:
+		r.Timestamp = t
+		return r
+	case domain.CO2Reading:
+		r.Timestamp = t
+		return r
+	case domain.PMS5003Reading:
+		r.Timestamp = t
+		return r
+	}
+	return reading
+}
@@ -0,0 +1,138 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+// Recorder implementa un publicador que graba la sesión completa en un archivo
+type Recorder struct {
+	path    string
+	mu      sync.Mutex
+	file    *os.File
+	w       *bufio.Writer
+	started time.Time
+	count   int
+	open    bool
+	logger  *slog.Logger
+}
+
+// NewRecorder crea un grabador de sesión
+func NewRecorder(path string) *Recorder {
+	return &Recorder{
+		path:   path,
+		logger: logging.For("session"),
+	}
+}
+
+// Connect crea el archivo y escribe la cabecera
+func (r *Recorder) Connect() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.open {
+		return nil
+	}
+
+	file, err := os.Create(r.path)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.w = bufio.NewWriter(file)
+	r.started = time.Now()
+	r.count = 0
+
+	if err := r.writeLine(header{Version: formatVersion, StartedAt: r.started}); err != nil {
+		file.Close()
+		return err
+	}
+	r.open = true
+
+	r.logger.Info("grabando sesión", "path", r.path)
+	return nil
+}
+
+// Publish agrega el mensaje con su desplazamiento desde el inicio
+func (r *Recorder) Publish(topic string, payload interface{}) error {
+	data, err := domain.MarshalReading(payload)
+	if err != nil {
+		r.logger.Error("error serializando payload", "topic", topic, "error", err)
+		return err
+	}
+
+	var table string
+	if record, ok := domain.ToRecord(payload); ok {
+		table = record.Table
+	} else if _, ok := payload.(domain.PMS5003Reading); ok {
+		table = tablePMS5003
+	}
+	_, binary := payload.([]byte)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.open {
+		return nil
+	}
+
+	offset := time.Since(r.started)
+	if err := r.writeLine(entry{
+		OffsetMs: float64(offset) / float64(time.Millisecond),
+		Topic:    topic,
+		Table:    table,
+		Binary:   binary,
+		Payload:  data,
+	}); err != nil {
+		r.logger.Error("error grabando mensaje", "topic", topic, "error", err)
+		return err
+	}
+	r.count++
+	return nil
+}
+
+// IsConnected indica si la grabación está abierta
+func (r *Recorder) IsConnected() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.open
+}
+
+// Disconnect cierra el archivo de sesión
+func (r *Recorder) Disconnect() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.open {
+		return
+	}
+	r.open = false
+
+	if err := r.file.Close(); err != nil {
+		r.logger.Error("error cerrando la sesión", "path", r.path, "error", err)
+	}
+	r.logger.Info("sesión grabada", "path", r.path, "messages", r.count)
+}
+
+// writeLine escribe y vacía cada línea para no perder mensajes si el proceso muere
+func (r *Recorder) writeLine(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := r.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := r.w.Flush(); err != nil {
+		return fmt.Errorf("session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package sqlsink
+
+import "simulador-hard/domain"
+
+// schema contiene el DDL de cada tabla documentada en domain.
+// Los tipos son lo bastante genéricos para SQLite, PostgreSQL y MySQL.
+// Las mediciones admiten NULL: un NaN o infinito (fallas nan/out_of_range)
+// se guarda como NULL.
+var schema = map[string]string{
+	domain.TableGasSensor: `CREATE TABLE IF NOT EXISTS gas_sensor (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	lpg DOUBLE PRECISION,
+	co DOUBLE PRECISION,
+	smoke DOUBLE PRECISION,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableParticleSensor: `CREATE TABLE IF NOT EXISTS particle_sensor (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	pm1_0 DOUBLE PRECISION,
+	pm2_5 DOUBLE PRECISION,
+	pm10 DOUBLE PRECISION,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableMotionSensors: `CREATE TABLE IF NOT EXISTS motion_sensors (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	motion_detected BOOLEAN NOT NULL,
+	intensity DOUBLE PRECISION,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableCameraCapture: `CREATE TABLE IF NOT EXISTS camera_capture (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	image_path TEXT NOT NULL,
+	motion_id VARCHAR(36) NOT NULL,
+	latency_ms INTEGER NOT NULL,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableCameraStream: `CREATE TABLE IF NOT EXISTS camera_stream (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	image_path TEXT NOT NULL,
+	system_id INTEGER NOT NULL,
+	latency_ms INTEGER NOT NULL
+)`,
+	domain.TableClimateSensor: `CREATE TABLE IF NOT EXISTS climate_sensor (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	temperature DOUBLE PRECISION,
+	humidity DOUBLE PRECISION,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableFlameSensor: `CREATE TABLE IF NOT EXISTS flame_sensor (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	flame_detected BOOLEAN NOT NULL,
+	ir_level INTEGER NOT NULL,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableSoundSensor: `CREATE TABLE IF NOT EXISTS sound_sensor (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	level_db DOUBLE PRECISION,
+	system_id INTEGER NOT NULL
+)`,
+	domain.TableCO2Sensor: `CREATE TABLE IF NOT EXISTS co2_sensor (
+	id VARCHAR(36) PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	co2 DOUBLE PRECISION,
+	warming BOOLEAN NOT NULL,
+	system_id INTEGER NOT NULL
+)`,
+}
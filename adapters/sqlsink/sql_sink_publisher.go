@@ -0,0 +1,328 @@
+package sqlsink
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite" // driver "sqlite" incluido por defecto
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+// DriverSQLite es el driver incluido con el simulador
+const DriverSQLite = "sqlite"
+
+// maxPendingBatches limita en lotes las lecturas que esperan reintento
+const maxPendingBatches = 10
+
+// Códigos primarios de SQLite que indican un bloqueo temporal
+const (
+	sqliteBusy   = 5
+	sqliteLocked = 6
+)
+
+// Config define la base de datos destino y el tamaño de los lotes.
+// Cualquier driver de database/sql sirve si se importa en main.
+type Config struct {
+	Driver        string
+	DSN           string
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// SQLSinkPublisher implementa un publicador que inserta las lecturas en SQL
+type SQLSinkPublisher struct {
+	cfg      Config
+	db       *sql.DB
+	mu       sync.Mutex
+	pending  []domain.Record
+	flushCh  chan struct{}
+	stopChan chan struct{}
+	doneChan chan struct{}
+	open     bool
+	logger   *slog.Logger
+}
+
+// NewSQLSinkPublisher crea un nuevo sink SQL
+func NewSQLSinkPublisher(cfg Config) *SQLSinkPublisher {
+	if cfg.Driver == "" {
+		cfg.Driver = DriverSQLite
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 2 * time.Second
+	}
+	return &SQLSinkPublisher{
+		cfg:    cfg,
+		logger: logging.For("sqlsink"),
+	}
+}
+
+// Connect abre la base de datos, crea las tablas e inicia el flush periódico
+func (p *SQLSinkPublisher) Connect() error {
+	db, err := sql.Open(p.cfg.Driver, p.cfg.DSN)
+	if err != nil {
+		return err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return err
+	}
+
+	for _, table := range domain.Tables {
+		if _, err := db.Exec(schema[table]); err != nil {
+			db.Close()
+			return fmt.Errorf("sqlsink: crear tabla %s: %w", table, err)
+		}
+	}
+
+	p.mu.Lock()
+	p.db = db
+	p.flushCh = make(chan struct{}, 1)
+	p.stopChan = make(chan struct{})
+	p.doneChan = make(chan struct{})
+	p.open = true
+	p.mu.Unlock()
+
+	go p.flushLoop()
+
+	p.logger.Info("base de datos conectada", "driver", p.cfg.Driver)
+	return nil
+}
+
+// Publish encola la lectura para insertarla en el próximo lote
+func (p *SQLSinkPublisher) Publish(topic string, payload interface{}) error {
+	// Las lecturas sin tabla (tramas crudas, PMS5003 extendida) no se guardan
+	record, ok := domain.ToRecord(payload)
+	if !ok {
+		p.logger.Debug("lectura sin tabla asociada", "topic", topic, "type", fmt.Sprintf("%T", payload))
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.open {
+		return nil
+	}
+
+	p.pending = append(p.pending, record)
+	if len(p.pending) >= p.cfg.BatchSize {
+		select {
+		case p.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// IsConnected indica si la base de datos está abierta
+func (p *SQLSinkPublisher) IsConnected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.open
+}
+
+// Disconnect inserta las lecturas pendientes y cierra la base de datos
+func (p *SQLSinkPublisher) Disconnect() {
+	p.mu.Lock()
+	if !p.open {
+		p.mu.Unlock()
+		return
+	}
+	p.open = false
+	p.mu.Unlock()
+
+	close(p.stopChan)
+	<-p.doneChan
+
+	if err := p.flush(); err != nil {
+		p.logger.Error("error insertando lote", "error", err)
+	}
+	p.db.Close()
+	p.logger.Info("base de datos desconectada")
+}
+
+func (p *SQLSinkPublisher) flushLoop() {
+	defer close(p.doneChan)
+
+	ticker := time.NewTicker(p.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopChan:
+			return
+		case <-ticker.C:
+		case <-p.flushCh:
+		}
+		if err := p.flush(); err != nil {
+			p.logger.Error("error insertando lote", "error", err)
+		}
+	}
+}
+
+// flush inserta el lote pendiente en una sola transacción. Cada fila va en
+// un savepoint: una fila inválida se descarta sin perder el resto del lote.
+// Si falla la transacción (por ejemplo SQLITE_BUSY) el lote vuelve a la cola.
+func (p *SQLSinkPublisher) flush() error {
+	p.mu.Lock()
+	batch := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	skipped, err := p.insertBatch(batch)
+	if err != nil {
+		p.requeue(batch)
+		return err
+	}
+	if skipped > 0 {
+		p.logger.Warn("lote insertado con filas descartadas", "rows", len(batch)-skipped, "skipped", skipped)
+	}
+	return nil
+}
+
+// requeue devuelve un lote fallido al frente de la cola; si se supera
+// maxPending se descartan las lecturas más viejas
+func (p *SQLSinkPublisher) requeue(batch []domain.Record) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending := append(batch, p.pending...)
+	limit := p.cfg.BatchSize * maxPendingBatches
+	if dropped := len(pending) - limit; dropped > 0 {
+		pending = pending[dropped:]
+		p.logger.Warn("cola de inserción llena, lecturas descartadas", "dropped", dropped)
+	}
+	p.pending = pending
+}
+
+// insertBatch retorna las filas descartadas o el error de la transacción
+func (p *SQLSinkPublisher) insertBatch(batch []domain.Record) (int, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	stmts := make(map[string]*sql.Stmt)
+	defer func() {
+		for _, stmt := range stmts {
+			stmt.Close()
+		}
+	}()
+
+	skipped := 0
+	for _, record := range batch {
+		stmt, ok := stmts[record.Table]
+		if !ok {
+			stmt, err = tx.Prepare(insertQuery(p.cfg.Driver, record))
+			if err != nil {
+				tx.Rollback()
+				return 0, err
+			}
+			stmts[record.Table] = stmt
+		}
+		if err := insertRow(tx, stmt, record); err != nil {
+			if errors.Is(err, errTransaction) {
+				tx.Rollback()
+				return 0, err
+			}
+			skipped++
+			p.logger.Warn("fila descartada", "table", record.Table, "error", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return skipped, nil
+}
+
+// errTransaction marca los errores que invalidan el lote entero y no solo la fila
+var errTransaction = errors.New("sqlsink: transacción")
+
+// insertRow inserta una fila; si falla, deshace solo esa fila
+func insertRow(tx *sql.Tx, stmt *sql.Stmt, record domain.Record) error {
+	if _, err := tx.Exec("SAVEPOINT row_insert"); err != nil {
+		return fmt.Errorf("%w: %v", errTransaction, err)
+	}
+	if _, err := stmt.Exec(sqlValues(record.Values)...); err != nil {
+		if transient(err) {
+			return fmt.Errorf("%w: insert %s: %v", errTransaction, record.Table, err)
+		}
+		if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT row_insert"); rbErr != nil {
+			return fmt.Errorf("%w: %v", errTransaction, rbErr)
+		}
+		return fmt.Errorf("insert %s: %w", record.Table, err)
+	}
+	if _, err := tx.Exec("RELEASE SAVEPOINT row_insert"); err != nil {
+		return fmt.Errorf("%w: %v", errTransaction, err)
+	}
+	return nil
+}
+
+// transient indica que el error no es de la fila sino de la base: bloqueo
+// (SQLITE_BUSY/SQLITE_LOCKED) o conexión caída; el lote se reintenta
+func transient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var coded interface{ Code() int }
+	if errors.As(err, &coded) {
+		switch coded.Code() & 0xFF {
+		case sqliteBusy, sqliteLocked:
+			return true
+		}
+	}
+	return false
+}
+
+// sqlValues reemplaza los float NaN o infinitos por NULL
+func sqlValues(values []interface{}) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			v = nil
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// insertQuery ignora los IDs repetidos, por ejemplo al reproducir una sesión
+// sin reescribir los timestamps
+func insertQuery(driver string, record domain.Record) string {
+	placeholders := make([]string, len(record.Columns))
+	for i := range placeholders {
+		placeholders[i] = placeholder(driver, i+1)
+	}
+	columns := strings.Join(record.Columns, ", ")
+	values := strings.Join(placeholders, ", ")
+	if driver == "mysql" {
+		return fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES (%s)", record.Table, columns, values)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (id) DO NOTHING", record.Table, columns, values)
+}
+
+// placeholder usa $n para los drivers de PostgreSQL y ? para el resto
+func placeholder(driver string, n int) string {
+	switch driver {
+	case "postgres", "pgx":
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
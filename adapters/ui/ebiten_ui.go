@@ -25,6 +25,7 @@ type EbitenUI struct {
 	mqttConnected   bool
 	time            float64
 	images          *imageCache
+	occupancy       OccupancyView
 }
 
 func NewEbitenUI(esp32s []ports.ESP32Simulator, usb ports.USBSimulator, mqttConnected bool) *EbitenUI {
@@ -59,6 +60,10 @@ func (ui *EbitenUI) Draw(screen *ebiten.Image) {
 
 	ui.drawUSBModule(screen, 980, 60)
 	ui.drawStatusPanel(screen, 1050, 420)
+
+	if ui.occupancy != nil {
+		ui.drawOccupancy(screen, 60, 510, 185)
+	}
 }
 
 func (ui *EbitenUI) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -206,7 +211,7 @@ func (ui *EbitenUI) drawGasSensor(screen *ebiten.Image, x, y float32, reading do
 	vector.DrawFilledCircle(screen, x+25, y+25, 22, color.RGBA{80, 80, 100, 255}, false)
 	vector.StrokeCircle(screen, x+25, y+25, 22, 2, color.RGBA{150, 150, 180, 255}, false)
 
-	hasAlert := reading.LPG > 700 || reading.CO > 700 || reading.Smoke > 700
+	hasAlert := reading.HasAlert()
 
 	gasColor := color.RGBA{0, 255, 100, 255}
 	if hasAlert {
@@ -237,7 +242,7 @@ func (ui *EbitenUI) drawParticleSensor(screen *ebiten.Image, x, y float32, readi
 			1, color.RGBA{100, 100, 120, 255}, false)
 	}
 
-	hasAlert := reading.PM25 > 75
+	hasAlert := reading.HasAlert()
 
 	particleColor := color.RGBA{255, 180, 100, 255}
 	if hasAlert {
@@ -437,10 +442,10 @@ func (ui *EbitenUI) drawStatusPanel(screen *ebiten.Image, x, y float32) {
 	for _, sim := range ui.esp32Simulators {
 		gas := sim.GetGasReading()
 		pm := sim.GetParticleReading()
-		if gas.LPG > 700 || gas.CO > 700 || gas.Smoke > 700 {
+		if gas.HasAlert() {
 			alertCnt++
 		}
-		if pm.PM25 > 75 {
+		if pm.HasAlert() {
 			alertCnt++
 		}
 	}
@@ -7,11 +7,16 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"log/slog"
 	"net/http"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+
+	"simulador-hard/logging"
 )
 
 type imageCache struct {
@@ -19,6 +24,7 @@ type imageCache struct {
 	cache   map[string]*ebiten.Image
 	loading map[string]bool
 	client  *http.Client
+	logger  *slog.Logger
 }
 
 func newImageCache() *imageCache {
@@ -26,6 +32,7 @@ func newImageCache() *imageCache {
 		cache:   make(map[string]*ebiten.Image),
 		loading: make(map[string]bool),
 		client:  &http.Client{Timeout: 10 * time.Second},
+		logger:  logging.For("ui"),
 	}
 }
 
@@ -40,7 +47,7 @@ func (c *imageCache) Get(url string) (*ebiten.Image, bool) {
 	return im, ok
 }
 
-// Load descarga la URL en background si no está en cache
+// Load descarga la URL (o lee el archivo local) en background si no está en cache
 func (c *imageCache) Load(url string) {
 	if url == "" {
 		return
@@ -61,20 +68,15 @@ func (c *imageCache) Load(url string) {
 			c.mu.Unlock()
 		}()
 
-		resp, err := c.client.Get(url)
+		data, err := c.fetch(url)
 		if err != nil {
-			return
-		}
-		defer resp.Body.Close()
-
-		// leer contenido
-		buf := &bytes.Buffer{}
-		if _, err := io.Copy(buf, resp.Body); err != nil {
+			c.logger.Debug("error descargando imagen", "url", url, "error", err)
 			return
 		}
 
-		img, _, err := image.Decode(bytes.NewReader(buf.Bytes()))
+		img, _, err := image.Decode(bytes.NewReader(data))
 		if err != nil {
+			c.logger.Debug("error decodificando imagen", "url", url, "error", err)
 			return
 		}
 
@@ -85,3 +87,23 @@ func (c *imageCache) Load(url string) {
 		c.mu.Unlock()
 	}()
 }
+
+// fetch lee una URL http(s) o, si no lo es, una ruta del disco
+func (c *imageCache) fetch(url string) ([]byte, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return os.ReadFile(url)
+	}
+
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// leer contenido
+	buf := &bytes.Buffer{}
+	if _, err := io.Copy(buf, resp.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"simulador-hard/domain"
+)
+
+// OccupancyView es la simulación de ocupación que dibuja el plano de la sala
+type OccupancyView interface {
+	Occupants() []domain.Occupant
+	Layout() domain.RoomLayout
+}
+
+// SetOccupancy activa el plano de la sala con las personas virtuales
+func (ui *EbitenUI) SetOccupancy(view OccupancyView) {
+	ui.occupancy = view
+}
+
+// drawOccupancy dibuja la sala vista desde arriba con el cono del PIR y los agentes
+func (ui *EbitenUI) drawOccupancy(screen *ebiten.Image, x, y, height float32) {
+	layout := ui.occupancy.Layout()
+	if layout.Depth <= 0 {
+		return
+	}
+	scale := (height - 20) / float32(layout.Depth)
+	width := float32(layout.Width) * scale
+	top := y + 20
+	at := func(p domain.Position) (float32, float32) {
+		return x + float32(p.X)*scale, top + float32(p.Y)*scale
+	}
+
+	occupants := ui.occupancy.Occupants()
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("SALA - %d personas", len(occupants)), int(x), int(y))
+
+	vector.DrawFilledRect(screen, x, top, width, height-20, color.RGBA{20, 25, 35, 230}, false)
+	vector.StrokeRect(screen, x, top, width, height-20, 2, color.RGBA{100, 150, 200, 200}, false)
+
+	// Cono del PIR
+	px, py := at(layout.PIR)
+	reach := float32(layout.PIRRange) * scale
+	for _, side := range []float64{-1, 1} {
+		angle := layout.PIRDirection + side*layout.PIRFOV/2
+		vector.StrokeLine(screen, px, py,
+			px+reach*float32(math.Cos(angle)), py+reach*float32(math.Sin(angle)),
+			1, color.RGBA{255, 200, 0, 120}, false)
+	}
+	vector.DrawFilledCircle(screen, px, py, 4, color.RGBA{255, 200, 0, 255}, false)
+
+	// Puerta
+	dx, dy := at(layout.Door)
+	vector.DrawFilledRect(screen, dx-3, dy-0.5*scale, 6, scale, color.RGBA{150, 100, 50, 255}, false)
+
+	for id, p := range layout.Mesas {
+		mx, my := at(p)
+		vector.DrawFilledRect(screen, mx-0.6*scale, my-0.3*scale, 1.2*scale, 0.6*scale, color.RGBA{139, 90, 43, 255}, false)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", id), int(mx-3), int(my-8))
+	}
+
+	for _, o := range occupants {
+		ox, oy := at(o.Position)
+		c := color.RGBA{0, 200, 255, 255}
+		switch o.Activity {
+		case domain.ActivityEntering:
+			c = color.RGBA{0, 255, 100, 255}
+		case domain.ActivityLeaving:
+			c = color.RGBA{255, 100, 100, 255}
+		}
+		if o.Moving {
+			vector.DrawFilledCircle(screen, ox, oy, 8, color.RGBA{c.R, c.G, c.B, 60}, false)
+		}
+		vector.DrawFilledCircle(screen, ox, oy, 4, c, false)
+	}
+}
@@ -0,0 +1,360 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
+)
+
+const (
+	clientSendBuffer = 64
+	writeWait        = 10 * time.Second
+	pongWait         = 60 * time.Second
+	pingPeriod       = 50 * time.Second
+)
+
+// Tipos de evento enviados a los navegadores
+const (
+	EventReading    = "reading"
+	EventAlert      = "alert"
+	EventConnection = "connection"
+)
+
+// Event es el sobre JSON que recibe cada cliente
+type Event struct {
+	Type      string      `json:"type"`
+	Topic     string      `json:"topic"`
+	Timestamp time.Time   `json:"timestamp"`
+	Payload   interface{} `json:"payload"`
+}
+
+// ConnectionStatus es el payload de los eventos de conexión
+type ConnectionStatus struct {
+	Source    string `json:"source"`
+	Connected bool   `json:"connected"`
+	Error     string `json:"error,omitempty"`
+}
+
+// clientCommand es el mensaje que envía un navegador para cambiar sus filtros
+type clientCommand struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+// LiveFeedServer implementa un servidor WebSocket que retransmite los datos
+type LiveFeedServer struct {
+	addr     string
+	upgrader websocket.Upgrader
+	server   *http.Server
+	mu       sync.RWMutex
+	clients  map[*feedClient]struct{}
+	running  bool
+	logger   *slog.Logger
+}
+
+type feedClient struct {
+	conn    *websocket.Conn
+	send    chan []byte
+	mu      sync.RWMutex
+	filters []string
+}
+
+// NewLiveFeedServer crea un nuevo servidor de feed en vivo
+func NewLiveFeedServer(addr string) *LiveFeedServer {
+	return &LiveFeedServer{
+		addr: addr,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     func(r *http.Request) bool { return true },
+		},
+		clients: make(map[*feedClient]struct{}),
+		logger:  logging.For("ws"),
+	}
+}
+
+// Connect abre el puerto y empieza a aceptar clientes
+func (s *LiveFeedServer) Connect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("websocket listen %s: %w", s.addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", s.handleWebSocket)
+	s.server = &http.Server{Handler: mux}
+	s.running = true
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("error del servidor WebSocket", "error", err)
+		}
+	}()
+
+	s.logger.Info("feed WebSocket escuchando", "url", fmt.Sprintf("ws://%s/ws", listener.Addr()))
+	return nil
+}
+
+// Publish envía una lectura a los clientes suscritos y las alertas que genere
+func (s *LiveFeedServer) Publish(topic string, payload interface{}) error {
+	if err := s.broadcast(Event{
+		Type:      EventReading,
+		Topic:     topic,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}); err != nil {
+		return err
+	}
+
+	for _, alert := range alertsFor(payload) {
+		alertTopic := fmt.Sprintf("vigiltech/alerts/mesa%d/%s", alert.SystemID, alert.Kind)
+		if err := s.broadcast(Event{
+			Type:      EventAlert,
+			Topic:     alertTopic,
+			Timestamp: time.Now(),
+			Payload:   alert,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PublishConnectionEvent notifica un cambio de conexión de otro adaptador
+func (s *LiveFeedServer) PublishConnectionEvent(source string, connected bool, cause error) {
+	status := ConnectionStatus{Source: source, Connected: connected}
+	if cause != nil {
+		status.Error = cause.Error()
+	}
+	if err := s.broadcast(Event{
+		Type:      EventConnection,
+		Topic:     "vigiltech/system/connection/" + source,
+		Timestamp: time.Now(),
+		Payload:   status,
+	}); err != nil {
+		s.logger.Error("error enviando evento de conexión", "error", err)
+	}
+}
+
+// IsConnected indica si el servidor está aceptando clientes
+func (s *LiveFeedServer) IsConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// Disconnect cierra el servidor y todas las conexiones abiertas
+func (s *LiveFeedServer) Disconnect() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	server := s.server
+	clients := s.clients
+	s.clients = make(map[*feedClient]struct{})
+	s.mu.Unlock()
+
+	server.Close()
+	for c := range clients {
+		close(c.send)
+	}
+	s.logger.Info("feed WebSocket detenido")
+}
+
+func (s *LiveFeedServer) broadcast(event Event) error {
+	data, err := domain.MarshalReading(event)
+	if err != nil {
+		s.logger.Error("error serializando evento", "topic", event.Topic, "error", err)
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for c := range s.clients {
+		if !c.subscribed(event.Topic) {
+			continue
+		}
+		select {
+		case c.send <- data:
+		default:
+			// Cliente lento: se descarta el mensaje para no bloquear los simuladores
+		}
+	}
+	return nil
+}
+
+func (s *LiveFeedServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Warn("error en upgrade WebSocket", "error", err)
+		return
+	}
+
+	// Filtros iniciales opcionales: /ws?topics=vigiltech/sensors/+/gas,vigiltech/alerts/#
+	filters := []string{"#"}
+	if query := r.URL.Query().Get("topics"); query != "" {
+		filters = splitTopics(query)
+	}
+
+	c := &feedClient{
+		conn:    conn,
+		send:    make(chan []byte, clientSendBuffer),
+		filters: filters,
+	}
+
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		conn.Close()
+		return
+	}
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	go c.writeLoop()
+	c.readLoop()
+
+	s.mu.Lock()
+	if _, ok := s.clients[c]; ok {
+		delete(s.clients, c)
+		close(c.send)
+	}
+	s.mu.Unlock()
+}
+
+// readLoop procesa los comandos subscribe/unsubscribe del navegador
+func (c *feedClient) readLoop() {
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		var cmd clientCommand
+		if err := c.conn.ReadJSON(&cmd); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				continue
+			}
+			return
+		}
+
+		switch cmd.Action {
+		case "subscribe":
+			c.subscribe(cmd.Topics)
+		case "unsubscribe":
+			c.unsubscribe(cmd.Topics)
+		}
+	}
+}
+
+func (c *feedClient) writeLoop() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case data, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *feedClient) subscribed(topic string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, filter := range c.filters {
+		if domain.MatchTopic(filter, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *feedClient) subscribe(topics []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, topic := range topics {
+		if !containsTopic(c.filters, topic) {
+			c.filters = append(c.filters, topic)
+		}
+	}
+}
+
+func (c *feedClient) unsubscribe(topics []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kept := c.filters[:0]
+	for _, filter := range c.filters {
+		if !containsTopic(topics, filter) {
+			kept = append(kept, filter)
+		}
+	}
+	c.filters = kept
+}
+
+func containsTopic(topics []string, topic string) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTopics(query string) []string {
+	var topics []string
+	for _, t := range strings.Split(query, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			topics = append(topics, t)
+		}
+	}
+	return topics
+}
+
+func alertsFor(payload interface{}) []domain.Alert {
+	switch r := payload.(type) {
+	case domain.GasReading:
+		return r.Alerts()
+	case domain.ParticleReading:
+		return r.Alerts()
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package application
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
+
+	"simulador-hard/domain"
+	"simulador-hard/logging"
 	"simulador-hard/ports"
 )
 
@@ -10,6 +14,8 @@ type SimulatorService struct {
 	esp32Simulators []ports.ESP32Simulator
 	usbSimulator    ports.USBSimulator
 	publisher       ports.DataPublisher
+	mqttStatus      func() bool
+	logger          *slog.Logger
 }
 
 // NewSimulatorService crea un nuevo servicio de simulación
@@ -22,27 +28,28 @@ func NewSimulatorService(
 		esp32Simulators: esp32s,
 		usbSimulator:    usb,
 		publisher:       publisher,
+		logger:          logging.For("app"),
 	}
 }
 
 // StartAll inicia todos los simuladores
 func (s *SimulatorService) StartAll() {
-	log.Println("Iniciando simuladores...")
+	s.logger.Info("iniciando simuladores")
 
 	// Iniciar ESP32s
 	for _, sim := range s.esp32Simulators {
 		sim.Start()
 	}
-	log.Printf("%d ESP32 simulados iniciados", len(s.esp32Simulators))
+	s.logger.Info("ESP32 simulados iniciados", "count", len(s.esp32Simulators))
 
 	// Iniciar USB
 	s.usbSimulator.Start()
-	log.Println("Sensores USB Direct iniciados")
+	s.logger.Info("sensores USB Direct iniciados")
 }
 
 // StopAll detiene todos los simuladores
 func (s *SimulatorService) StopAll() {
-	log.Println("Deteniendo simuladores...")
+	s.logger.Info("deteniendo simuladores")
 
 	// Detener ESP32s
 	for _, sim := range s.esp32Simulators {
@@ -57,7 +64,7 @@ func (s *SimulatorService) StopAll() {
 		s.publisher.Disconnect()
 	}
 
-	log.Println("Simuladores detenidos correctamente")
+	s.logger.Info("simuladores detenidos correctamente")
 }
 
 // GetESP32Simulators retorna los simuladores ESP32
@@ -70,10 +77,92 @@ func (s *SimulatorService) GetUSBSimulator() ports.USBSimulator {
 	return s.usbSimulator
 }
 
+// SetMQTTStatus indica cómo consultar la conexión con el broker MQTT. El
+// publicador del servicio es el fanout, que está conectado si lo está
+// cualquiera de sus adaptadores.
+func (s *SimulatorService) SetMQTTStatus(connected func() bool) {
+	s.mqttStatus = connected
+}
+
 // IsMQTTConnected verifica si MQTT está conectado
 func (s *SimulatorService) IsMQTTConnected() bool {
-	if s.publisher == nil {
+	if s.mqttStatus == nil {
 		return false
 	}
-	return s.publisher.IsConnected()
-}
\ No newline at end of file
+	return s.mqttStatus()
+}
+
+// ListDevices lista los ESP32 y el módulo USB con su estado
+func (s *SimulatorService) ListDevices() []domain.DeviceInfo {
+	devices := make([]domain.DeviceInfo, 0, len(s.esp32Simulators)+1)
+	for _, sim := range s.esp32Simulators {
+		devices = append(devices, domain.DeviceInfo{
+			ID:      domain.ESP32DeviceID(sim.GetMesaID()),
+			Kind:    domain.DeviceKindESP32,
+			Running: sim.IsRunning(),
+		})
+	}
+	devices = append(devices, domain.DeviceInfo{
+		ID:      domain.USBDeviceID,
+		Kind:    domain.DeviceKindUSB,
+		Running: s.usbSimulator.IsRunning(),
+	})
+	return devices
+}
+
+// StartDevice arranca un dispositivo por su ID
+func (s *SimulatorService) StartDevice(id string) (domain.DeviceInfo, error) {
+	sim, info, err := s.findDevice(id)
+	if err != nil {
+		return info, err
+	}
+	sim.Start()
+	info.Running = sim.IsRunning()
+	s.logger.Info("dispositivo iniciado", "device", id)
+	return info, nil
+}
+
+// StopDevice detiene un dispositivo por su ID
+func (s *SimulatorService) StopDevice(id string) (domain.DeviceInfo, error) {
+	sim, info, err := s.findDevice(id)
+	if err != nil {
+		return info, err
+	}
+	sim.Stop()
+	info.Running = sim.IsRunning()
+	s.logger.Info("dispositivo detenido", "device", id)
+	return info, nil
+}
+
+// Snapshot retorna la última lectura de cada sensor
+func (s *SimulatorService) Snapshot() *domain.SystemState {
+	state := &domain.SystemState{
+		ESP32States:   make(map[int]*domain.ESP32State),
+		MQTTConnected: s.IsMQTTConnected(),
+	}
+	for _, sim := range s.esp32Simulators {
+		state.ESP32States[sim.GetMesaID()] = &domain.ESP32State{
+			MesaID:       sim.GetMesaID(),
+			LastGas:      sim.GetGasReading(),
+			LastParticle: sim.GetParticleReading(),
+		}
+	}
+	state.USBState = &domain.USBState{
+		LastMotion:       s.usbSimulator.GetMotionReading(),
+		LastCamera:       s.usbSimulator.GetCameraReading(),
+		LastCameraStream: s.usbSimulator.GetCameraStreamReading(),
+	}
+	return state
+}
+
+func (s *SimulatorService) findDevice(id string) (ports.SensorSimulator, domain.DeviceInfo, error) {
+	if id == domain.USBDeviceID {
+		return s.usbSimulator, domain.DeviceInfo{ID: id, Kind: domain.DeviceKindUSB}, nil
+	}
+	for _, sim := range s.esp32Simulators {
+		if domain.ESP32DeviceID(sim.GetMesaID()) == id {
+			return sim, domain.DeviceInfo{ID: id, Kind: domain.DeviceKindESP32}, nil
+		}
+	}
+	return nil, domain.DeviceInfo{ID: id}, fmt.Errorf("%w: %s", domain.ErrDeviceNotFound, id)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// Umbrales de alerta usados por la UI y por los consumidores de eventos
+const (
+	GasAlertThreshold  = 700.0
+	PM25AlertThreshold = 75.0
+)
+
+// Alert representa una lectura que superó su umbral de alerta
+type Alert struct {
+	SensorID  string    `json:"sensor_id"`
+	SystemID  int       `json:"system_id"`
+	ReadingID string    `json:"reading_id"`
+	Kind      string    `json:"kind"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HasAlert indica si algún gas supera el umbral
+func (r GasReading) HasAlert() bool {
+	return len(r.Alerts()) > 0
+}
+
+// Alerts retorna una alerta por cada gas que supera el umbral
+func (r GasReading) Alerts() []Alert {
+	var alerts []Alert
+	values := []struct {
+		kind  string
+		value float64
+	}{
+		{"lpg", r.LPG},
+		{"co", r.CO},
+		{"smoke", r.Smoke},
+	}
+	for _, v := range values {
+		if v.value > GasAlertThreshold {
+			alerts = append(alerts, Alert{
+				SensorID:  r.SensorID,
+				SystemID:  r.SystemID,
+				ReadingID: r.ID,
+				Kind:      v.kind,
+				Value:     v.value,
+				Threshold: GasAlertThreshold,
+				Timestamp: r.Timestamp,
+			})
+		}
+	}
+	return alerts
+}
+
+// HasAlert indica si PM2.5 supera el umbral
+func (r ParticleReading) HasAlert() bool {
+	return r.PM25 > PM25AlertThreshold
+}
+
+// Alerts retorna la alerta de PM2.5 si corresponde
+func (r ParticleReading) Alerts() []Alert {
+	if !r.HasAlert() {
+		return nil
+	}
+	return []Alert{{
+		SensorID:  r.SensorID,
+		SystemID:  r.SystemID,
+		ReadingID: r.ID,
+		Kind:      "pm2_5",
+		Value:     r.PM25,
+		Threshold: PM25AlertThreshold,
+		Timestamp: r.Timestamp,
+	}}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Tipos de dispositivo simulado
+const (
+	DeviceKindESP32 = "esp32"
+	DeviceKindUSB   = "usb"
+)
+
+// USBDeviceID identifica al módulo USB conectado a la Raspberry Pi
+const USBDeviceID = "usb"
+
+// ErrDeviceNotFound se retorna cuando el ID no corresponde a ningún dispositivo
+var ErrDeviceNotFound = errors.New("dispositivo no encontrado")
+
+// DeviceInfo describe un dispositivo simulado y su estado
+type DeviceInfo struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Running bool   `json:"running"`
+}
+
+// ESP32DeviceID retorna el ID del ESP32 de una mesa ("mesa1", "mesa2"...)
+func ESP32DeviceID(mesaID int) string {
+	return fmt.Sprintf("mesa%d", mesaID)
+}
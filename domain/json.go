@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	anyType           = reflect.TypeFor[interface{}]()
+	nullFloat32Type   = reflect.TypeFor[nullFloat32]()
+	nullFloat64Type   = reflect.TypeFor[nullFloat64]()
+)
+
+// nullFloat64 y nullFloat32 se serializan como null si no son finitos. Siguen
+// siendo float para encoding/json, así que omitempty solo omite el cero.
+type nullFloat64 float64
+
+func (f nullFloat64) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(float64(f))
+}
+
+type nullFloat32 float32
+
+func (f nullFloat32) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(float32(f))
+}
+
+// MarshalReading serializa una lectura como JSON. A diferencia de json.Marshal,
+// los valores NaN e infinitos se escriben como null, igual que ArduinoJson en
+// el firmware del ESP32, en lugar de fallar.
+func MarshalReading(payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	var unsupported *json.UnsupportedValueError
+	if err == nil || !errors.As(err, &unsupported) || payload == nil {
+		return data, err
+	}
+
+	v := reflect.ValueOf(payload)
+	return json.Marshal(nullableValue(v, nullableType(v.Type())).Interface())
+}
+
+// nullableType retorna un tipo equivalente para encoding/json donde cada float
+// es un nullFloat64 o nullFloat32
+func nullableType(t reflect.Type) reflect.Type {
+	if isMarshaler(t) {
+		return t
+	}
+
+	switch t.Kind() {
+	case reflect.Float32:
+		return nullFloat32Type
+	case reflect.Float64:
+		return nullFloat64Type
+	case reflect.Ptr:
+		return reflect.PointerTo(nullableType(t.Elem()))
+	case reflect.Interface:
+		return anyType
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return t // []byte se serializa en base64
+		}
+		return reflect.SliceOf(nullableType(t.Elem()))
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), nullableType(t.Elem()))
+	case reflect.Map:
+		return reflect.MapOf(t.Key(), nullableType(t.Elem()))
+	case reflect.Struct:
+		fields := make([]reflect.StructField, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !isJSONField(f) {
+				continue
+			}
+			name := f.Name
+			if !f.IsExported() {
+				// StructOf no acepta campos no exportados; el nombre de un campo
+				// embebido sin tag no llega al JSON, solo sus campos promovidos
+				name = "Embedded_" + f.Name
+			}
+			fields = append(fields, reflect.StructField{
+				Name:      name,
+				Type:      nullableType(f.Type),
+				Tag:       f.Tag,
+				Anonymous: f.Anonymous,
+			})
+		}
+		return reflect.StructOf(fields)
+	}
+	return t
+}
+
+// nullableValue copia v al tipo t de nullableType
+func nullableValue(v reflect.Value, t reflect.Type) reflect.Value {
+	// Un tipo igual al original puede contener interfaces con float adentro,
+	// así que solo se reutiliza tal cual si serializa por su cuenta
+	if isMarshaler(v.Type()) || (v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		out := reflect.New(t).Elem()
+		out.SetFloat(v.Float())
+		return out
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(nullableValue(v.Elem(), t.Elem()))
+		return p
+
+	case reflect.Interface:
+		out := reflect.New(t).Elem()
+		if !v.IsNil() {
+			inner := v.Elem()
+			out.Set(nullableValue(inner, nullableType(inner.Type())))
+		}
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(nullableValue(v.Index(i), t.Elem()))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(nullableValue(v.Index(i), t.Elem()))
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+		out := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), nullableValue(iter.Value(), t.Elem()))
+		}
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		j := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !isJSONField(f) {
+				continue
+			}
+			field := v.Field(i)
+			if !f.IsExported() {
+				field = embeddedField(v, i)
+			}
+			out.Field(j).Set(nullableValue(field, t.Field(j).Type))
+			j++
+		}
+		return out
+	}
+	return v
+}
+
+// isJSONField replica el criterio de encoding/json: los campos exportados y los
+// structs embebidos, aunque no se exporten, porque sus campos se promueven
+func isJSONField(f reflect.StructField) bool {
+	if f.IsExported() {
+		return true
+	}
+	if !f.Anonymous {
+		return false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// embeddedField retorna el campo i de v sin la marca de solo lectura que reflect
+// pone a los campos no exportados, para poder copiar sus campos promovidos
+func embeddedField(v reflect.Value, i int) reflect.Value {
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+	f := v.Field(i)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+func isMarshaler(t reflect.Type) bool {
+	p := reflect.PointerTo(t)
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		p.Implements(jsonMarshalerType) || p.Implements(textMarshalerType)
+}
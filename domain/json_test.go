@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+type jsonBase struct {
+	ID    string  `json:"id"`
+	Value float64 `json:"value"`
+}
+
+type JSONBase struct {
+	ID    string  `json:"id"`
+	Value float64 `json:"value"`
+}
+
+type jsonStamp struct {
+	At time.Time `json:"at"`
+}
+
+func TestMarshalReading(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+	stamp := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		payload interface{}
+		want    string
+	}{
+		{
+			name:    "lectura sin NaN",
+			payload: GasReading{ID: "g1", SystemID: 1, LPG: 1.5, CO: 2, Smoke: 3, Timestamp: stamp},
+			want:    `{"id":"g1","sensor_id":"","system_id":1,"lpg":1.5,"co":2,"smoke":3,"timestamp":"2026-10-18T10:00:00Z"}`,
+		},
+		{
+			name:    "lectura con NaN e infinito",
+			payload: GasReading{ID: "g1", SystemID: 1, LPG: nan, CO: inf, Smoke: 3, Timestamp: stamp},
+			want:    `{"id":"g1","sensor_id":"","system_id":1,"lpg":null,"co":null,"smoke":3,"timestamp":"2026-10-18T10:00:00Z"}`,
+		},
+		{
+			name: "struct anidado",
+			payload: struct {
+				A     float64
+				Inner struct{ B float64 }
+			}{A: 1, Inner: struct{ B float64 }{B: nan}},
+			want: `{"A":1,"Inner":{"B":null}}`,
+		},
+		{
+			name: "punteros",
+			payload: &struct {
+				P   *float64
+				Nil *float64
+				S   *struct{ V float64 }
+			}{P: &nan, S: &struct{ V float64 }{V: inf}},
+			want: `{"P":null,"Nil":null,"S":{"V":null}}`,
+		},
+		{
+			name:    "map",
+			payload: map[string]float64{"a": nan, "b": 2},
+			want:    `{"a":null,"b":2}`,
+		},
+		{
+			name:    "interfaces",
+			payload: map[string]interface{}{"a": nan, "b": []interface{}{1.5, inf}, "c": "x"},
+			want:    `{"a":null,"b":[1.5,null],"c":"x"}`,
+		},
+		{
+			name: "slice y array",
+			payload: struct {
+				S []float64
+				A [2]float64
+			}{S: []float64{nan, 1}, A: [2]float64{2, inf}},
+			want: `{"S":[null,1],"A":[2,null]}`,
+		},
+		{
+			name: "omitempty",
+			payload: struct {
+				Zero  float64 `json:"zero,omitempty"`
+				NaN   float64 `json:"nan,omitempty"`
+				Empty string  `json:"empty,omitempty"`
+				Value float64 `json:"value"`
+			}{NaN: nan, Value: 1},
+			want: `{"nan":null,"value":1}`,
+		},
+		{
+			name: "time.Time",
+			payload: struct {
+				At    time.Time `json:"at"`
+				Value float64   `json:"value"`
+			}{At: stamp, Value: nan},
+			want: `{"at":"2026-10-18T10:00:00Z","value":null}`,
+		},
+		{
+			name: "[]byte",
+			payload: struct {
+				Raw   []byte
+				Value float64
+			}{Raw: []byte{0x42, 0x4d}, Value: nan},
+			want: `{"Raw":"Qk0=","Value":null}`,
+		},
+		{
+			name: "struct embebido exportado",
+			payload: struct {
+				JSONBase
+				Extra float64 `json:"extra"`
+			}{JSONBase: JSONBase{ID: "b", Value: nan}, Extra: 1},
+			want: `{"id":"b","value":null,"extra":1}`,
+		},
+		{
+			name: "struct embebido no exportado",
+			payload: struct {
+				jsonBase
+				jsonStamp
+				Extra float64 `json:"extra"`
+			}{jsonBase: jsonBase{ID: "b", Value: nan}, jsonStamp: jsonStamp{At: stamp}, Extra: 1},
+			want: `{"id":"b","value":null,"at":"2026-10-18T10:00:00Z","extra":1}`,
+		},
+		{
+			name: "puntero a struct embebido no exportado",
+			payload: struct {
+				*jsonBase
+				Extra float64 `json:"extra"`
+			}{jsonBase: &jsonBase{ID: "b", Value: inf}, Extra: 1},
+			want: `{"id":"b","value":null,"extra":1}`,
+		},
+		{
+			name: "campo externo tapa al promovido",
+			payload: struct {
+				jsonBase
+				Value float64 `json:"value"`
+			}{jsonBase: jsonBase{ID: "b", Value: 1}, Value: nan},
+			want: `{"id":"b","value":null}`,
+		},
+	}
+	for _, tt := range tests {
+		got, err := MarshalReading(tt.payload)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s:\n got  %s\n want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalReadingNil(t *testing.T) {
+	got, err := MarshalReading(nil)
+	if err != nil || string(got) != "null" {
+		t.Errorf("MarshalReading(nil) = %s, %v", got, err)
+	}
+}
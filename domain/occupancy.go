@@ -0,0 +1,35 @@
+package domain
+
+// Position es un punto de la sala en metros desde la esquina de la puerta
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Actividades de un ocupante
+const (
+	ActivityEntering = "entering"
+	ActivityWorking  = "working"
+	ActivityWalking  = "walking"
+	ActivityLeaving  = "leaving"
+)
+
+// Occupant es una persona virtual de la simulación de ocupación
+type Occupant struct {
+	ID       int      `json:"id"`
+	Position Position `json:"position"`
+	Activity string   `json:"activity"`
+	Mesa     int      `json:"mesa"` // mesa destino o actual
+	Moving   bool     `json:"moving"`
+}
+
+// RoomLayout es la geometría de la sala para dibujarla
+type RoomLayout struct {
+	Width, Depth float64
+	Door         Position
+	Mesas        map[int]Position
+	PIR          Position
+	PIRDirection float64 // radianes, 0 hacia +X
+	PIRFOV       float64 // apertura total en radianes
+	PIRRange     float64 // metros
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Record es una fila lista para persistir en la tabla documentada de cada lectura
+type Record struct {
+	Table   string
+	Columns []string
+	Values  []interface{}
+}
+
+// Nombres de las tablas del backend
+const (
+	TableGasSensor      = "gas_sensor"
+	TableParticleSensor = "particle_sensor"
+	TableMotionSensors  = "motion_sensors"
+	TableCameraCapture  = "camera_capture"
+	TableCameraStream   = "camera_stream"
+	TableClimateSensor  = "climate_sensor"
+	TableFlameSensor    = "flame_sensor"
+	TableSoundSensor    = "sound_sensor"
+	TableCO2Sensor      = "co2_sensor"
+)
+
+// Tables lista las tablas en el orden en que se documentan
+var Tables = []string{
+	TableGasSensor,
+	TableParticleSensor,
+	TableMotionSensors,
+	TableCameraCapture,
+	TableCameraStream,
+	TableClimateSensor,
+	TableFlameSensor,
+	TableSoundSensor,
+	TableCO2Sensor,
+}
+
+// ToRecord convierte una lectura en su fila de base de datos.
+// Retorna false si el payload no corresponde a ninguna tabla.
+func ToRecord(payload interface{}) (Record, bool) {
+	switch r := payload.(type) {
+	case GasReading:
+		return Record{
+			Table:   TableGasSensor,
+			Columns: []string{"id", "timestamp", "lpg", "co", "smoke", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.LPG, r.CO, r.Smoke, r.SystemID},
+		}, true
+	case ParticleReading:
+		return Record{
+			Table:   TableParticleSensor,
+			Columns: []string{"id", "timestamp", "pm1_0", "pm2_5", "pm10", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.PM10, r.PM25, r.PM100, r.SystemID},
+		}, true
+	case MotionReading:
+		return Record{
+			Table:   TableMotionSensors,
+			Columns: []string{"id", "timestamp", "motion_detected", "intensity", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.MotionDetected, r.Intensity, r.SystemID},
+		}, true
+	case CameraReading:
+		return Record{
+			Table:   TableCameraCapture,
+			Columns: []string{"id", "timestamp", "image_path", "motion_id", "latency_ms", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.ImagePath, r.MotionID, r.LatencyMs, r.SystemID},
+		}, true
+	case CameraStreamReading:
+		return Record{
+			Table:   TableCameraStream,
+			Columns: []string{"id", "timestamp", "image_path", "system_id", "latency_ms"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.ImagePath, r.SystemID, r.LatencyMs},
+		}, true
+	case ClimateReading:
+		return Record{
+			Table:   TableClimateSensor,
+			Columns: []string{"id", "timestamp", "temperature", "humidity", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.Temperature, r.Humidity, r.SystemID},
+		}, true
+	case FlameReading:
+		return Record{
+			Table:   TableFlameSensor,
+			Columns: []string{"id", "timestamp", "flame_detected", "ir_level", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.FlameDetected, r.IRLevel, r.SystemID},
+		}, true
+	case SoundReading:
+		return Record{
+			Table:   TableSoundSensor,
+			Columns: []string{"id", "timestamp", "level_db", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.LevelDB, r.SystemID},
+		}, true
+	case CO2Reading:
+		return Record{
+			Table:   TableCO2Sensor,
+			Columns: []string{"id", "timestamp", "co2", "warming", "system_id"},
+			Values:  []interface{}{r.ID, r.Timestamp, r.CO2, r.Warming, r.SystemID},
+		}, true
+	}
+	return Record{}, false
+}
+
+// DecodeReading reconstruye la lectura de una tabla a partir de su JSON
+func DecodeReading(table string, data []byte) (interface{}, error) {
+	var err error
+	switch table {
+	case TableGasSensor:
+		var r GasReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableParticleSensor:
+		var r ParticleReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableMotionSensors:
+		var r MotionReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableCameraCapture:
+		var r CameraReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableCameraStream:
+		var r CameraStreamReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableClimateSensor:
+		var r ClimateReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableFlameSensor:
+		var r FlameReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableSoundSensor:
+		var r SoundReading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case TableCO2Sensor:
+		var r CO2Reading
+		err = json.Unmarshal(data, &r)
+		return r, err
+	}
+	return nil, fmt.Errorf("tabla desconocida %q", table)
+}
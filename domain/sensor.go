@@ -12,6 +12,19 @@ type GasReading struct {
 	CO        float64   `json:"co"`
 	Smoke     float64   `json:"smoke"`
 	Timestamp time.Time `json:"timestamp"`
+	// Raw trae los conteos del ADC de los que se derivan los ppm; no se persiste
+	Raw *MQ135Raw `json:"raw,omitempty"`
+}
+
+// MQ135Raw son los valores crudos del MQ-135 (ADC de 12 bits del ESP32) y el
+// ambiente usado para la compensación
+type MQ135Raw struct {
+	ADCLPG      int     `json:"adc_lpg"`
+	ADCCO       int     `json:"adc_co"`
+	ADCSmoke    int     `json:"adc_smoke"`
+	Temperature float64 `json:"temperature"`
+	Humidity    float64 `json:"humidity"`
+	Warming     bool    `json:"warming"`
 }
 
 // ParticleReading representa una lectura del sensor de partículas PMS5003
@@ -26,6 +39,77 @@ type ParticleReading struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// PMS5003Reading es la versión extendida de ParticleReading con todos los
+// campos de la trama del PMS5003; no tiene tabla propia en el backend
+type PMS5003Reading struct {
+	ID       string `json:"id"`
+	SensorID string `json:"sensor_id"`
+	SystemID int    `json:"system_id"`
+	PM1CF1   int    `json:"pm1_0_cf1"`
+	PM25CF1  int    `json:"pm2_5_cf1"`
+	PM10CF1  int    `json:"pm10_cf1"`
+	PM1Atm   int    `json:"pm1_0_atm"`
+	PM25Atm  int    `json:"pm2_5_atm"`
+	PM10Atm  int    `json:"pm10_atm"`
+	// Partículas mayores que cada tamaño por 0.1 L de aire
+	Count03   int       `json:"gt_0_3um"`
+	Count05   int       `json:"gt_0_5um"`
+	Count10   int       `json:"gt_1_0um"`
+	Count25   int       `json:"gt_2_5um"`
+	Count50   int       `json:"gt_5_0um"`
+	Count100  int       `json:"gt_10um"`
+	Version   int       `json:"version"`
+	ErrorCode int       `json:"error_code"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Lecturas de los sensores opcionales del ESP32. Sus tablas no son parte del
+// esquema documentado del backend: las crean los sinks del simulador.
+
+// ClimateReading es una lectura del DHT22 (temperatura y humedad)
+// Se guarda en: climate_sensor (id, timestamp, temperature, humidity, system_id)
+type ClimateReading struct {
+	ID          string    `json:"id"`
+	SensorID    string    `json:"sensor_id"`
+	SystemID    int       `json:"system_id"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// FlameReading es una lectura del módulo de llama IR: la salida analógica del
+// fototransistor (ADC de 12 bits) y la salida digital del comparador
+// Se guarda en: flame_sensor (id, timestamp, flame_detected, ir_level, system_id)
+type FlameReading struct {
+	ID            string    `json:"id"`
+	SensorID      string    `json:"sensor_id"`
+	SystemID      int       `json:"system_id"`
+	FlameDetected bool      `json:"flame_detected"`
+	IRLevel       int       `json:"ir_level"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// SoundReading es el nivel sonoro ponderado A del micrófono de la mesa
+// Se guarda en: sound_sensor (id, timestamp, level_db, system_id)
+type SoundReading struct {
+	ID        string    `json:"id"`
+	SensorID  string    `json:"sensor_id"`
+	SystemID  int       `json:"system_id"`
+	LevelDB   float64   `json:"level_db"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CO2Reading es una lectura del MH-Z19 (NDIR); Warming indica el precalentamiento
+// Se guarda en: co2_sensor (id, timestamp, co2, warming, system_id)
+type CO2Reading struct {
+	ID        string    `json:"id"`
+	SensorID  string    `json:"sensor_id"`
+	SystemID  int       `json:"system_id"`
+	CO2       float64   `json:"co2"`
+	Warming   bool      `json:"warming"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // MotionReading representa una lectura del sensor PIR HC-SR501
 // Mapea exactamente a: motion_sensors (id, timestamp, motion_detected, intensity, system_id)
 type MotionReading struct {
@@ -35,6 +119,8 @@ type MotionReading struct {
 	MotionDetected bool      `json:"motion_detected"`
 	Intensity      float64   `json:"intensity"`
 	Timestamp      time.Time `json:"timestamp"`
+	// Metadata lleva el contexto de traza W3C (traceparent); no se persiste
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // CameraReading representa una captura de cámara cuando hay movimiento
@@ -47,6 +133,8 @@ type CameraReading struct {
 	MotionID  string    `json:"motion_id"`
 	LatencyMs int       `json:"latency_ms"`
 	Timestamp time.Time `json:"timestamp"`
+	// Metadata lleva el contexto de traza W3C (traceparent); no se persiste
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // CameraStreamReading representa el stream continuo de cámara (cada segundo)
@@ -0,0 +1,26 @@
+package domain
+
+import "strings"
+
+// MatchTopic compara un topic contra un filtro estilo MQTT.
+// '+' coincide con un nivel y '#' con todos los niveles restantes.
+func MatchTopic(filter, topic string) bool {
+	if filter == "" {
+		return false
+	}
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) {
+			return false
+		}
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
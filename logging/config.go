@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// ConfigFromEnv completa cfg con LOG_LEVEL, LOG_FORMAT y LOG_LEVELS.
+// LOG_LEVELS tiene la forma "mqtt=debug,usb=warn".
+func ConfigFromEnv(cfg Config) (Config, error) {
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		level, err := ParseLevel(v)
+		if err != nil {
+			return cfg, fmt.Errorf("LOG_LEVEL: %w", err)
+		}
+		cfg.Level = level
+	}
+
+	switch strings.ToLower(os.Getenv("LOG_FORMAT")) {
+	case "json":
+		cfg.JSON = true
+	case "text":
+		cfg.JSON = false
+	}
+
+	if v := os.Getenv("LOG_LEVELS"); v != "" {
+		if cfg.ComponentLevels == nil {
+			cfg.ComponentLevels = make(map[string]slog.Level)
+		}
+		for _, pair := range strings.Split(v, ",") {
+			component, levelText, ok := strings.Cut(pair, "=")
+			if !ok {
+				return cfg, fmt.Errorf("LOG_LEVELS: entrada inválida %q", pair)
+			}
+			level, err := ParseLevel(levelText)
+			if err != nil {
+				return cfg, fmt.Errorf("LOG_LEVELS %s: %w", component, err)
+			}
+			cfg.ComponentLevels[strings.TrimSpace(component)] = level
+		}
+	}
+	return cfg, nil
+}
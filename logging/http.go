@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// levelStatus es la respuesta JSON del endpoint de niveles
+type levelStatus struct {
+	Component string    `json:"component"`
+	Level     string    `json:"level"`
+	Sampling  *Sampling `json:"sampling,omitempty"`
+}
+
+// Handler permite consultar y cambiar niveles y muestreo en caliente:
+//
+//	GET  /debug/log?component=mqtt
+//	POST /debug/log?component=mqtt&level=debug
+//	POST /debug/log?component=usb&sample_first=5&sample_thereafter=50&sample_tick=1s
+//	POST /debug/log?component=usb&sample=off
+//
+// Sin component se opera sobre el nivel por defecto.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		component := q.Get("component")
+
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			if v := q.Get("level"); v != "" {
+				level, err := ParseLevel(v)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				if component == "" {
+					SetDefaultLevel(level)
+				} else {
+					SetLevel(component, level)
+				}
+			}
+
+			if component != "" && q.Get("sample") == "off" {
+				DisableSampling(component)
+			} else if component != "" && q.Has("sample_first") {
+				cfg, err := parseSampling(q.Get("sample_first"), q.Get("sample_thereafter"), q.Get("sample_tick"))
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				SetSampling(component, cfg)
+			}
+		} else if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		status := levelStatus{Component: component, Level: Level(component).String()}
+		if cfg, ok := SamplingFor(component); ok {
+			status.Sampling = &cfg
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+}
+
+func parseSampling(first, thereafter, tick string) (Sampling, error) {
+	var cfg Sampling
+	var err error
+	if cfg.First, err = strconv.Atoi(first); err != nil {
+		return cfg, err
+	}
+	if thereafter != "" {
+		if cfg.Thereafter, err = strconv.Atoi(thereafter); err != nil {
+			return cfg, err
+		}
+	}
+	if tick != "" {
+		if cfg.Tick, err = time.ParseDuration(tick); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
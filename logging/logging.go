@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Config define el formato de salida y los niveles iniciales
+type Config struct {
+	Level           slog.Level
+	JSON            bool
+	Output          io.Writer
+	ComponentLevels map[string]slog.Level
+}
+
+var (
+	mu       sync.RWMutex
+	root     slog.Handler = slog.NewTextHandler(os.Stderr, nil)
+	defLevel              = new(slog.LevelVar)
+	levels                = make(map[string]*slog.LevelVar)
+	samplers              = make(map[string]*sampler)
+)
+
+// Setup configura el handler raíz y redirige el paquete log estándar
+func Setup(cfg Config) {
+	out := cfg.Output
+	if out == nil {
+		out = os.Stderr
+	}
+
+	// El filtrado por nivel lo hace cada componente, el handler raíz acepta todo
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var h slog.Handler
+	if cfg.JSON {
+		h = slog.NewJSONHandler(out, opts)
+	} else {
+		h = slog.NewTextHandler(out, opts)
+	}
+
+	mu.Lock()
+	root = h
+	defLevel.Set(cfg.Level)
+	for component, level := range cfg.ComponentLevels {
+		levelVar(component).Set(level)
+	}
+	mu.Unlock()
+
+	slog.SetDefault(For("main"))
+}
+
+// For retorna el logger de un componente (mqtt, esp32, usb, ui...)
+func For(component string) *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return slog.New(&componentHandler{
+		inner:     root.WithAttrs([]slog.Attr{slog.String("component", component)}),
+		component: component,
+	})
+}
+
+// SetLevel cambia en caliente el nivel de un componente
+func SetLevel(component string, level slog.Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	levelVar(component).Set(level)
+}
+
+// SetDefaultLevel cambia en caliente el nivel de los componentes sin nivel propio
+func SetDefaultLevel(level slog.Level) {
+	defLevel.Set(level)
+}
+
+// Level retorna el nivel efectivo de un componente
+func Level(component string) slog.Level {
+	mu.RLock()
+	defer mu.RUnlock()
+	if lv, ok := levels[component]; ok {
+		return lv.Level()
+	}
+	return defLevel.Level()
+}
+
+// ParseLevel interpreta debug, info, warn o error
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(strings.TrimSpace(s)))
+	return level, err
+}
+
+func levelVar(component string) *slog.LevelVar {
+	lv, ok := levels[component]
+	if !ok {
+		lv = new(slog.LevelVar)
+		lv.Set(defLevel.Level())
+		levels[component] = lv
+	}
+	return lv
+}
+
+// componentHandler filtra por el nivel del componente y aplica el muestreo
+type componentHandler struct {
+	inner     slog.Handler
+	component string
+}
+
+func (h *componentHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= Level(h.component)
+}
+
+func (h *componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	mu.RLock()
+	s := samplers[h.component]
+	mu.RUnlock()
+
+	if s != nil && !s.allow(r.Level, r.Message) {
+		return nil
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &componentHandler{inner: h.inner.WithAttrs(attrs), component: h.component}
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return &componentHandler{inner: h.inner.WithGroup(name), component: h.component}
+}
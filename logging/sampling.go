@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Sampling limita los mensajes repetidos de un componente: en cada ventana
+// Tick se emiten los primeros First mensajes iguales y luego uno de cada
+// Thereafter. Las advertencias y errores nunca se muestrean.
+type Sampling struct {
+	Tick       time.Duration
+	First      int
+	Thereafter int
+}
+
+type sampler struct {
+	cfg     Sampling
+	mu      sync.Mutex
+	resetAt time.Time
+	counts  map[string]int
+}
+
+// SetSampling activa o reemplaza en caliente el muestreo de un componente
+func SetSampling(component string, cfg Sampling) {
+	if cfg.Tick <= 0 {
+		cfg.Tick = time.Second
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	samplers[component] = &sampler{cfg: cfg, counts: make(map[string]int)}
+}
+
+// DisableSampling desactiva el muestreo de un componente
+func DisableSampling(component string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(samplers, component)
+}
+
+// SamplingFor retorna el muestreo activo de un componente
+func SamplingFor(component string) (Sampling, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	s, ok := samplers[component]
+	if !ok {
+		return Sampling{}, false
+	}
+	return s.cfg, true
+}
+
+func (s *sampler) allow(level slog.Level, msg string) bool {
+	if level >= slog.LevelWarn {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.After(s.resetAt) {
+		s.resetAt = now.Add(s.cfg.Tick)
+		clear(s.counts)
+	}
+
+	s.counts[msg]++
+	n := s.counts[msg]
+	if n <= s.cfg.First {
+		return true
+	}
+	return s.cfg.Thereafter > 0 && (n-s.cfg.First)%s.cfg.Thereafter == 0
+}
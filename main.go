@@ -1,16 +1,39 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"math/rand"
+	"path/filepath"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
+	"simulador-hard/adapters/coap"
+	"simulador-hard/adapters/fanout"
+	"simulador-hard/adapters/faults"
+	"simulador-hard/adapters/filesink"
+	"simulador-hard/adapters/grpcapi"
 	"simulador-hard/adapters/hardware"
+	"simulador-hard/adapters/hardware/hcsr501"
+	"simulador-hard/adapters/hardware/occupancy"
+	"simulador-hard/adapters/hardware/room"
+	"simulador-hard/adapters/hardware/webcam"
+	"simulador-hard/adapters/influx"
+	"simulador-hard/adapters/metrics"
+	"simulador-hard/adapters/modbus"
 	"simulador-hard/adapters/mqtt"
+	"simulador-hard/adapters/scenario"
+	"simulador-hard/adapters/serial"
+	"simulador-hard/adapters/session"
+	"simulador-hard/adapters/sqlsink"
+	"simulador-hard/adapters/tracing"
 	"simulador-hard/adapters/ui"
+	"simulador-hard/adapters/websocket"
 	"simulador-hard/application"
+	"simulador-hard/logging"
 	"simulador-hard/ports"
 )
 
@@ -19,41 +42,452 @@ const (
 	MQTT_BROKER  = "tcp://52.45.244.182:1883"
 	MQTT_ENABLED = true 
 	NUM_MESAS    = 4
+
+	// Sala compartida: las fugas se difunden entre mesas en lugar de picos independientes
+	ROOM_ENABLED = true
+	// Personas virtuales que entran, trabajan en las mesas y se van según el
+	// horario; mueven el PIR USB y levantan partículas
+	OCCUPANCY_ENABLED = true
+	OCCUPANCY_PEOPLE  = 0 // > 0 mantiene esa cantidad e ignora el horario
+
+	// El humo de cada mesa arrastra CO y partículas (smoke -> co, pm2_5, pm10)
+	CORRELATION_ENABLED = true
+
+	WS_ENABLED = true
+	WS_ADDR    = ":8081"
+
+	GRPC_ENABLED = true
+	GRPC_ADDR    = ":50051"
+
+	// POST CON/NON al servidor y, opcionalmente, recursos observables locales
+	COAP_ENABLED      = false
+	COAP_SERVER       = "localhost:5683"
+	COAP_CONFIRMABLE  = true
+	COAP_OBSERVE_ADDR = ":5685" // "" para no exponer recursos
+
+	// Grabación y reproducción de sesiones completas ("" desactiva)
+	SESSION_RECORD_FILE  = ""   // ej: "session.jsonl"
+	SESSION_REPLAY_FILE  = ""   // si se indica, se reproduce en lugar de simular
+	SESSION_REPLAY_SPEED = 1.0  // 1 tiempo original, 2 doble, 0 sin esperas
+	SESSION_REPLAY_NOW   = true // reescribe los timestamps al momento de publicar
+
+	// Mapa de registros en adapters/modbus/register_map.go
+	MODBUS_ENABLED = true
+	MODBUS_ADDR    = ":5020"
+
+	// Sensores de cada ESP32: mq135, pms5003, dht22, flame, sound, mhz19
+	ESP32_SENSORS = "mq135,pms5003"
+
+	// HC-SR501 del módulo USB: retención (3s-5m), jumper H redisparable / L único,
+	// sensibilidad 0 (3 m) a 1 (7 m)
+	PIR_HOLD        = 5 * time.Second
+	PIR_REPEATABLE  = true
+	PIR_SENSITIVITY = 0.5
+
+	// Imágenes sintéticas de la webcam USB, sin internet. Con CAMERA_HTTP_ADDR
+	// vacío ImagePath es la ruta del archivo en lugar de una URL
+	CAMERA_IMAGES_ENABLED = true
+	CAMERA_IMAGE_DIR      = "camera"
+	CAMERA_HTTP_ADDR      = ":8082"
+	CAMERA_WIDTH          = 640
+	CAMERA_HEIGHT         = 480
+	CAMERA_MAX_FILES      = webcam.DefaultMaxFiles
+
+	// Stream MJPEG continuo en http://<CAMERA_HTTP_ADDR>/stream.mjpg, como una
+	// cámara IP; los frames muestran el estado actual del PIR
+	MJPEG_ENABLED = true
+	MJPEG_WIDTH   = 640
+	MJPEG_HEIGHT  = 480
+	MJPEG_FPS     = 10.0
+	MJPEG_QUALITY = 70
+
+	// Un pty por ESP32 con enlace /tmp/ttyESP32-<mesa> (solo Linux)
+	SERIAL_ENABLED  = false
+	SERIAL_LINK_DIR = "/tmp"
+
+	// Tramas PMS5003 de 32 bytes: pty /tmp/ttyPMS5003-<mesa>, MQTT crudo y lectura extendida
+	PMS5003_PTY          = false
+	PMS5003_RAW_FRAMES   = false
+	PMS5003_EXTENDED     = false
+	PMS5003_CORRUPT_RATE = 0.0 // probabilidad de enviar una trama dañada
+
+	FILE_SINK_ENABLED  = false
+	FILE_SINK_DIR      = "recordings"
+	FILE_SINK_FORMAT   = filesink.FormatJSONL // o filesink.FormatCSV
+	FILE_SINK_MAX_MB   = 50
+	FILE_SINK_MAX_AGE  = time.Hour
+	FILE_SINK_COMPRESS = true
+
+	SQL_SINK_ENABLED = false
+	SQL_SINK_DRIVER  = sqlsink.DriverSQLite
+	SQL_SINK_DSN     = "file:simulador.db?_pragma=journal_mode(WAL)"
+	SQL_SINK_BATCH   = 100
+
+	INFLUX_ENABLED = false
+	INFLUX_FILE    = "readings.lp"
+	INFLUX_URL     = "" // ej: http://localhost:8086/api/v2/write?org=vigiltech&bucket=sim&precision=ns
+	INFLUX_TOKEN   = ""
+
+	// Reglas de falla iniciales (JSON); se agregan en caliente con POST /faults.
+	// La API /faults comparte el puerto METRICS_ADDR: sin METRICS_ENABLED solo
+	// quedan las reglas de FAULTS_FILE y las de los escenarios
+	FAULTS_FILE = ""
+
+	// Incidente guionado (formato en adapters/scenario/parser.go); "" desactiva
+	SCENARIO_FILE = ""
+	// Preset incluido si no hay archivo: normal-lab-day, fire:<mesa>, gas-leak:<mesa>,
+	// intrusion-night, sensor-degradation, broker-outage:<mesa>
+	SCENARIO_PRESET = ""
+
+	// Prometheus en /metrics; el mismo servidor expone /debug/log y /faults
+	METRICS_ENABLED = true
+	METRICS_ADDR    = ":9100"
+
+	TRACING_EXPORTER = tracing.ExporterNone // o tracing.ExporterStdout / tracing.ExporterOTLP
+	OTLP_ENDPOINT    = "localhost:4318"
+
+	// Sobrescribibles con LOG_LEVEL, LOG_FORMAT y LOG_LEVELS
+	LOG_LEVEL = slog.LevelInfo
+	LOG_JSON  = false
 )
 
 func main() {
 	rand.Seed(time.Now().UnixNano())
 
+	setupLogging()
 	printBanner()
 
+	//Configurar trazas OpenTelemetry
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:     TRACING_EXPORTER,
+		OTLPEndpoint: OTLP_ENDPOINT,
+		OTLPInsecure: true,
+		ServiceName:  "vigiltech-hardware-simulator",
+	})
+	if err != nil {
+		slog.Warn("no se pudo iniciar el exportador de trazas", "error", err)
+	} else {
+		defer shutdownTracing(context.Background())
+	}
+
+	var publishers []ports.DataPublisher
+	// Publicadores que un escenario puede desconectar por nombre
+	namedPublishers := make(map[string]ports.DataPublisher)
+
+	var promMetrics *metrics.Metrics
+	if METRICS_ENABLED {
+		promMetrics = metrics.NewMetrics()
+	}
+
+	//Configurar feed WebSocket para el dashboard
+	var liveFeed *websocket.LiveFeedServer
+	if WS_ENABLED {
+		liveFeed = websocket.NewLiveFeedServer(WS_ADDR)
+		if err := liveFeed.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar el feed WebSocket", "error", err)
+			liveFeed = nil
+		} else {
+			publishers = append(publishers, liveFeed)
+		}
+	}
+
+	//Configurar API gRPC
+	var grpcServer *grpcapi.SimulatorServer
+	if GRPC_ENABLED {
+		grpcServer = grpcapi.NewSimulatorServer(GRPC_ADDR)
+		if err := grpcServer.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar la API gRPC", "error", err)
+			grpcServer = nil
+		} else {
+			publishers = append(publishers, grpcServer)
+		}
+	}
+
+	//Configurar servidor Modbus TCP para la integración del edificio
+	if MODBUS_ENABLED {
+		modbusServer := modbus.NewModbusServer(MODBUS_ADDR, NUM_MESAS)
+		if err := modbusServer.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar el servidor Modbus", "error", err)
+		} else {
+			publishers = append(publishers, modbusServer)
+			defer modbusServer.Disconnect()
+		}
+	}
+
+	//Configurar publicador CoAP para comparar con MQTT
+	if COAP_ENABLED {
+		coapPub := coap.NewCoAPPublisher(coap.Config{
+			ServerAddr:  COAP_SERVER,
+			Confirmable: COAP_CONFIRMABLE,
+			ObserveAddr: COAP_OBSERVE_ADDR,
+		})
+		if err := coapPub.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar el publicador CoAP", "error", err)
+		} else {
+			publishers = append(publishers, coapPub)
+			namedPublishers["coap"] = coapPub
+			defer coapPub.Disconnect()
+		}
+	}
+
+	//Configurar grabación local
+	if FILE_SINK_ENABLED {
+		fileSink := filesink.NewFileSinkPublisher(filesink.Config{
+			Dir:      FILE_SINK_DIR,
+			Format:   FILE_SINK_FORMAT,
+			MaxBytes: FILE_SINK_MAX_MB * 1024 * 1024,
+			MaxAge:   FILE_SINK_MAX_AGE,
+			Compress: FILE_SINK_COMPRESS,
+		})
+		if err := fileSink.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar la grabación local", "error", err)
+		} else {
+			publishers = append(publishers, fileSink)
+		}
+	}
+
+	//Configurar inserción directa en base de datos
+	if SQL_SINK_ENABLED {
+		sqlSink := sqlsink.NewSQLSinkPublisher(sqlsink.Config{
+			Driver:    SQL_SINK_DRIVER,
+			DSN:       SQL_SINK_DSN,
+			BatchSize: SQL_SINK_BATCH,
+		})
+		if err := sqlSink.Connect(); err != nil {
+			slog.Warn("no se pudo conectar a la base de datos", "error", err)
+		} else {
+			publishers = append(publishers, sqlSink)
+		}
+	}
+
+	//Configurar exportación a InfluxDB
+	if INFLUX_ENABLED {
+		influxPub := influx.NewInfluxPublisher(influx.Config{
+			FilePath: INFLUX_FILE,
+			URL:      INFLUX_URL,
+			Token:    INFLUX_TOKEN,
+		})
+		if err := influxPub.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar el exportador InfluxDB", "error", err)
+		} else {
+			publishers = append(publishers, influxPub)
+		}
+	}
+
+	//Configurar grabación de la sesión
+	if SESSION_RECORD_FILE != "" {
+		recorder := session.NewRecorder(SESSION_RECORD_FILE)
+		if err := recorder.Connect(); err != nil {
+			slog.Warn("no se pudo iniciar la grabación de sesión", "error", err)
+		} else {
+			publishers = append(publishers, recorder)
+			defer recorder.Disconnect()
+		}
+	}
+
 	//Configurar MQTT Publisher 
-	var publisher ports.DataPublisher
 	mqttConnected := false
+	var mqttStatus func() bool
 
 	if MQTT_ENABLED {
 		mqttPub := mqtt.NewMQTTPublisher(MQTT_BROKER, "vigiltech-hardware-simulator")
+		mqttStatus = mqttPub.IsConnected
+		if promMetrics != nil {
+			promMetrics.RegisterConnectionState("mqtt", mqttPub.IsConnected)
+		}
+		if liveFeed != nil {
+			mqttPub.SetConnectionListener(func(connected bool, err error) {
+				liveFeed.PublishConnectionEvent("mqtt", connected, err)
+			})
+		}
 
 		if err := mqttPub.Connect(); err != nil {
-			log.Printf("No se pudo conectar a MQTT: %v", err)
-			log.Println("Continuando solo con visualización...")
-			publisher = nil
+			slog.Warn("no se pudo conectar a MQTT, continuando solo con visualización", "error", err)
+			if liveFeed != nil {
+				liveFeed.PublishConnectionEvent("mqtt", false, err)
+			}
 		} else {
-			publisher = mqttPub
+			publishers = append(publishers, mqttPub)
+			namedPublishers["mqtt"] = mqttPub
+			namedPublishers["broker"] = mqttPub
 			mqttConnected = true
-			log.Println("MQTT conectado - Publicando datos")
+			slog.Info("MQTT conectado, publicando datos")
 		}
 	} else {
-		log.Println("MQTT deshabilitado - Solo visualización")
+		slog.Info("MQTT deshabilitado, solo visualización")
+	}
+
+	var publisher ports.DataPublisher
+	if len(publishers) > 0 {
+		publisher = fanout.NewFanoutPublisher(publishers...)
+	}
+
+	//Configurar inyección de fallas de sensores
+	faultInjector := faults.NewInjector()
+	if FAULTS_FILE != "" {
+		if err := faultInjector.LoadRules(FAULTS_FILE); err != nil {
+			slog.Warn("no se pudieron cargar las reglas de falla", "error", err)
+		}
+	}
+
+	//Configurar endpoint de métricas Prometheus
+	if promMetrics != nil {
+		if publisher != nil {
+			publisher = metrics.NewInstrumentedPublisher(publisher, promMetrics)
+		}
+		metricsServer := metrics.NewMetricsServer(METRICS_ADDR, promMetrics)
+		metricsServer.Handle("/debug/log", logging.Handler())
+		metricsServer.Handle("/faults", faults.Handler(faultInjector))
+		metricsServer.Handle("/faults/", faults.Handler(faultInjector))
+		if err := metricsServer.Start(); err != nil {
+			slog.Warn("no se pudo iniciar el endpoint de métricas", "error", err)
+		} else {
+			defer metricsServer.Stop()
+		}
+	}
+
+	// Las fallas se aplican antes de todo lo demás, como si vinieran del sensor
+	if publisher != nil {
+		publisher = faults.NewFaultyPublisher(publisher, faultInjector)
+	}
+
+	//Crear la sala con la posición de cada mesa
+	var roomModel *room.Room
+	var environment hardware.Environment
+	if ROOM_ENABLED {
+		roomModel = room.New(room.DefaultConfig(NUM_MESAS), time.Now())
+		environment = hardware.NewRoomEnvironment(roomModel)
+	}
+
+	//Cargar el escenario; sus fuentes se suman al ambiente de cada mesa
+	var incident *scenario.Scenario
+	var overlay *scenario.Overlay
+	if SCENARIO_FILE != "" || SCENARIO_PRESET != "" {
+		var err error
+		if SCENARIO_FILE != "" {
+			incident, err = scenario.Load(SCENARIO_FILE)
+		} else {
+			incident, err = scenario.LoadPreset(SCENARIO_PRESET)
+		}
+		if err != nil {
+			slog.Warn("no se pudo cargar el escenario", "error", err)
+			incident = nil
+		} else {
+			overlay = scenario.NewOverlay(environment)
+			environment = overlay
+		}
+	}
+
+	//Simular la ocupación de la sala
+	var occupants *occupancy.Model
+	if OCCUPANCY_ENABLED {
+		occupancyConfig := occupancy.DefaultConfig(room.DefaultConfig(NUM_MESAS))
+		occupancyConfig.Fixed = OCCUPANCY_PEOPLE
+		occupants = occupancy.New(occupancyConfig, time.Now())
+		environment = occupancy.NewEnvironment(environment, occupants)
+	}
+
+	//Correlacionar humo, CO y partículas sobre todo lo anterior
+	if CORRELATION_ENABLED {
+		correlation := hardware.DefaultCorrelation()
+		if ROOM_ENABLED {
+			// Los episodios de humo ya vienen de las fugas de la sala
+			correlation.Combustion = nil
+		}
+		environment = hardware.NewCorrelatedEnvironment(environment, correlation)
 	}
 
 	//Crear simuladores ESP32 (Adaptadores Primarios)
 	esp32Simulators := make([]ports.ESP32Simulator, NUM_MESAS)
 	for i := 1; i <= NUM_MESAS; i++ {
-		esp32Simulators[i-1] = hardware.NewESP32Simulator(i, publisher)
+		esp32 := hardware.NewESP32Simulator(i, publisher)
+		if sensors, err := hardware.NewSensors(ESP32_SENSORS); err != nil {
+			slog.Warn("configuración de sensores inválida, usando los por defecto", "error", err)
+		} else {
+			esp32.SetSensors(sensors)
+		}
+		if ROOM_ENABLED {
+			esp32.SetSignalConfig(hardware.BaselineSignalConfig())
+		} else if CORRELATION_ENABLED {
+			esp32.SetSignalConfig(hardware.CorrelatedSignalConfig())
+		}
+		if environment != nil {
+			esp32.SetEnvironment(environment)
+		}
+		esp32.SetFaultInjector(faultInjector)
+		if SERIAL_ENABLED {
+			port := serial.NewSerialPort(i, filepath.Join(SERIAL_LINK_DIR, fmt.Sprintf("ttyESP32-%d", i)))
+			if err := port.Connect(); err != nil {
+				slog.Warn("no se pudo abrir el puerto serie emulado", "mesa", i, "error", err)
+			} else {
+				port.SetDevice(esp32)
+				esp32.SetSerialPort(port)
+				defer port.Disconnect()
+			}
+		}
+		esp32.SetPMS5003Config(hardware.PMS5003Config{
+			RawFrames:   PMS5003_RAW_FRAMES,
+			Extended:    PMS5003_EXTENDED,
+			CorruptRate: PMS5003_CORRUPT_RATE,
+		})
+		if PMS5003_PTY {
+			pmsPort := serial.NewFramePort(filepath.Join(SERIAL_LINK_DIR, fmt.Sprintf("ttyPMS5003-%d", i)))
+			if err := pmsPort.Connect(); err != nil {
+				slog.Warn("no se pudo abrir el puerto PMS5003 emulado", "mesa", i, "error", err)
+			} else {
+				esp32.SetPMS5003Port(pmsPort)
+				defer pmsPort.Disconnect()
+			}
+		}
+		esp32Simulators[i-1] = esp32
 	}
 
 	//Crear simulador USB Direct (Adaptador Primario)
 	usbSimulator := hardware.NewUSBSimulator(publisher)
+	pirConfig := hcsr501.DefaultConfig()
+	pirConfig.Hold = PIR_HOLD
+	pirConfig.Sensitivity = PIR_SENSITIVITY
+	if !PIR_REPEATABLE {
+		pirConfig.Mode = hcsr501.Single
+	}
+	usbSimulator.SetPIRConfig(pirConfig)
+	if occupants != nil {
+		occupants.SetPIRConfig(pirConfig)
+		usbSimulator.SetPresenceSource(occupants)
+	}
+	cameraURL := ""
+	if CAMERA_HTTP_ADDR != "" && (CAMERA_IMAGES_ENABLED || MJPEG_ENABLED) {
+		cameraServer := webcam.NewServer(CAMERA_HTTP_ADDR, CAMERA_IMAGE_DIR)
+		if MJPEG_ENABLED {
+			streamConfig := webcam.DefaultStreamConfig()
+			streamConfig.Width = MJPEG_WIDTH
+			streamConfig.Height = MJPEG_HEIGHT
+			streamConfig.FPS = MJPEG_FPS
+			streamConfig.Quality = MJPEG_QUALITY
+			cameraServer.Handle("/stream.mjpg", webcam.NewStream(streamConfig, usbSimulator))
+		}
+		if err := cameraServer.Start(); err != nil {
+			slog.Warn("no se pudo iniciar el servidor de la cámara, se usan rutas locales", "error", err)
+		} else {
+			defer cameraServer.Stop()
+			cameraURL = "http://" + localAddr(CAMERA_HTTP_ADDR)
+			if MJPEG_ENABLED {
+				slog.Info("stream MJPEG disponible", "url", cameraURL+"/stream.mjpg", "fps", MJPEG_FPS)
+			}
+		}
+	}
+	if CAMERA_IMAGES_ENABLED {
+		baseURL := ""
+		if cameraURL != "" {
+			baseURL = cameraURL + "/images"
+		}
+		store, err := webcam.NewStore(CAMERA_IMAGE_DIR, baseURL, CAMERA_MAX_FILES)
+		if err != nil {
+			slog.Warn("no se pudo crear el directorio de imágenes, se usa picsum", "error", err)
+		} else {
+			usbSimulator.SetCamera(webcam.NewCamera(webcam.NewRenderer(CAMERA_WIDTH, CAMERA_HEIGHT), store))
+		}
+	}
 
 
 	//Crear servicio de simulación
@@ -62,16 +496,52 @@ func main() {
 		usbSimulator,
 		publisher,
 	)
+	if mqttStatus != nil {
+		simulatorService.SetMQTTStatus(mqttStatus)
+	}
+	if grpcServer != nil {
+		grpcServer.SetController(simulatorService)
+	}
+
+	//Iniciar todos los simuladores o reproducir una sesión grabada
+	if SESSION_REPLAY_FILE != "" && publisher != nil {
+		ctx, cancelReplay := context.WithCancel(context.Background())
+		defer cancelReplay()
+		player := session.NewPlayer(SESSION_REPLAY_FILE, session.PlayerConfig{
+			Speed:             SESSION_REPLAY_SPEED,
+			RewriteTimestamps: SESSION_REPLAY_NOW,
+		})
+		go func() {
+			if _, err := player.Play(ctx, publisher); err != nil && ctx.Err() == nil {
+				slog.Error("error reproduciendo la sesión", "error", err)
+			}
+		}()
+	} else {
+		simulatorService.StartAll()
+	}
 
-	//Iniciar todos los simuladores
-	simulatorService.StartAll()
+	//Ejecutar el escenario sobre la simulación en curso
+	if incident != nil {
+		ctx, cancelScenario := context.WithCancel(context.Background())
+		defer cancelScenario()
+		engine := scenario.NewEngine(incident, scenario.Targets{
+			Overlay:    overlay,
+			Room:       roomModel,
+			Motion:     usbSimulator,
+			Devices:    simulatorService,
+			Faults:     faultInjector,
+			Publishers: namedPublishers,
+		})
+		go engine.Run(ctx)
+	}
 
-	log.Println("========================================")
-	log.Println("Iniciando visualización gráfica...")
-	log.Println("========================================")
+	slog.Info("iniciando visualización gráfica")
 
 	//Crear interfaz Ebiten
 	game := ui.NewEbitenUI(esp32Simulators, usbSimulator, mqttConnected)
+	if occupants != nil {
+		game.SetOccupancy(occupants)
+	}
 
 	//Configurar ventana
 	ebiten.SetWindowSize(1280, 700)
@@ -87,10 +557,35 @@ func main() {
 	}
 }
 
+func setupLogging() {
+	cfg, err := logging.ConfigFromEnv(logging.Config{
+		Level: LOG_LEVEL,
+		JSON:  LOG_JSON,
+	})
+	logging.Setup(cfg)
+	if err != nil {
+		slog.Warn("configuración de logging inválida", "error", err)
+	}
+
+	// Eventos de alta frecuencia: 10 mensajes iguales por segundo y luego 1 de cada 100
+	highRate := logging.Sampling{Tick: time.Second, First: 10, Thereafter: 100}
+	for _, component := range []string{"mqtt", "esp32", "usb"} {
+		logging.SetSampling(component, highRate)
+	}
+}
+
 func printBanner() {
 	log.Println("========================================")
 	log.Println("  VIGILTECH - Simulador de Hardware")
 	log.Println("  Arquitectura Hexagonal")
 	log.Println("========================================")
 	log.Println("")
-}
\ No newline at end of file
+}
+
+// localAddr completa ":8082" como "localhost:8082" para armar URLs
+func localAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
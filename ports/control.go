@@ -0,0 +1,11 @@
+package ports
+
+import "simulador-hard/domain"
+
+//define el contrato para controlar los dispositivos simulados
+type DeviceController interface {
+	ListDevices() []domain.DeviceInfo
+	StartDevice(id string) (domain.DeviceInfo, error)
+	StopDevice(id string) (domain.DeviceInfo, error)
+	Snapshot() *domain.SystemState
+}
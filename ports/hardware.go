@@ -6,6 +6,7 @@ import "simulador-hard/domain"
 type SensorSimulator interface {
 	Start()
 	Stop()
+	IsRunning() bool
 	GetState() interface{}
 }
 
@@ -15,6 +16,8 @@ type ESP32Simulator interface {
 	GetMesaID() int
 	GetGasReading() domain.GasReading
 	GetParticleReading() domain.ParticleReading
+	// GetSensorReading retorna la última lectura de cualquier sensor montado
+	GetSensorReading(kind string) (interface{}, bool)
 }
 
 //define el contrato para simulador USB
@@ -22,4 +25,5 @@ type USBSimulator interface {
 	SensorSimulator
 	GetMotionReading() domain.MotionReading
 	GetCameraReading() domain.CameraReading
+	GetCameraStreamReading() domain.CameraStreamReading
 }
\ No newline at end of file